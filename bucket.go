@@ -0,0 +1,274 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Bucketer assigns moments to buckets (periods) for aggregation,
+// e.g. every event to the day, the week or the quart it happens in,
+// or to a window of 15 minutes.
+//
+// A bucketer takes a snapshot of the settings of the period factory
+// and its time factory and resolves the rules of the unit once,
+// so Bucket neither looks the rules up nor locks, and the later changes
+// of the factories don't affect it. The factories of other packages
+// can't be snapshotted, so their Make method is called for every moment.
+//
+// It's safe for concurrent use by multiple goroutines.
+// The last bucket is cached, so the sorted moments are the fastest ones.
+type Bucketer struct {
+	sc PeriodShortcut
+	// width and origin are the settings of the fixed width buckets,
+	// width is zero for the buckets of units.
+	width, origin time.Duration
+	// ok is false if the unit is unknown or its rules aren't found.
+	ok bool
+
+	// pf and tf are the snapshots of the factories,
+	// they are nil if the factories are of other packages.
+	// The time factory of pf is tf if it's snapshotted,
+	// so the buckets never see the later changes.
+	pf *unsafePeriodFactory
+	tf *unsafeTimeFactory
+	// rule is the snapshotted period rule of the shortcut,
+	// start and end are set only if rule is the default one,
+	// so the bounds are calculated straight by the time rules.
+	rule       PeriodRule
+	start, end TimeRule
+	// f is the period factory of the slow path.
+	f PeriodMaker
+
+	last atomic.Value // *bucket
+}
+
+type bucket struct {
+	key int64
+	p   Period
+	loc *time.Location
+}
+
+// NewBucketer creates a bucketer of the calendar unit, e.g. the buckets
+// of UnitWeek are the periods "this week" of the moments, so they follow
+// StartOfWeek, the fiscal year, the day start, the location and so on.
+// The buckets are the same periods which Make of the factory returns
+// for the shortcut "this <unit>", so Prev, Next, Split and the other
+// methods work with them. The rules registered for the shortcut
+// by WithPeriodRules or Extend are run as well.
+// If the unit is unknown or its shortcut is removed from the factory,
+// Bucket never succeeds.
+func NewBucketer(f PeriodMaker, u Unit) *Bucketer {
+	b := &Bucketer{f: f}
+	if !u.valid() {
+		return b
+	}
+
+	b.sc, _ = periodExpr{unit: unit(u)}.shortcut()
+	b.ok = true
+
+	var ok bool
+	if b.pf, ok = snapshotPeriodFactory(f); !ok {
+		return b
+	}
+
+	if b.rule, ok = b.pf.rule(b.sc); !ok {
+		b.pf, b.ok = nil, false
+		return b
+	}
+
+	var rules []TimeRule
+	if b.tf, rules, ok = snapshotTimeFactory(b.pf.tf,
+		unit(u).startShortcut(), unit(u).endShortcut()); !ok {
+		b.pf, b.rule = nil, nil
+		return b
+	}
+
+	b.pf.tf = b.tf
+	if isDefaultPeriodRule(b.rule) {
+		b.start, b.end = rules[0], rules[1]
+		b.ok = b.start != nil && b.end != nil
+	}
+
+	return b
+}
+
+// isDefaultPeriodRule reports whether the rule is one of the rules
+// which the package registers or builds by the period grammar,
+// they are the same as the time rules of the start and the end of the unit.
+func isDefaultPeriodRule(r PeriodRule) bool {
+	if _, ok := r.(*periodRuleExpr); ok {
+		return true
+	}
+
+	for _, d := range defaultPeriodRules {
+		if r == d {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewFixedBucketer creates a bucketer of the buckets of the fixed width,
+// e.g. 15*time.Minute. The buckets are aligned to the Unix epoch
+// moved by the origin, e.g. the width of 15 minutes with the origin
+// of 5 minutes makes the buckets which start at 00:05, 00:20, 00:35
+// and so on. The alignment doesn't depend on the locations, so the hours
+// in a location with a half-hour offset need the origin of 30 minutes.
+// The buckets have no shortcut, they get the stringer, the interval mode,
+// the location and the precision of the factories.
+// If the width isn't positive, Bucket never succeeds.
+func NewFixedBucketer(f PeriodMaker, width, origin time.Duration) *Bucketer {
+	b := &Bucketer{f: f, width: width, origin: origin, ok: width > 0}
+
+	var ok bool
+	if b.pf, ok = snapshotPeriodFactory(f); ok {
+		if b.tf, _, ok = snapshotTimeFactory(b.pf.tf); ok {
+			b.pf.tf = b.tf
+		}
+	}
+
+	return b
+}
+
+// Bucket returns the bucket of the moment and its key.
+// The key is the start of the bucket in Unix nanoseconds, so it's stable
+// for the same settings and the keys are sorted as the buckets are.
+// The period of a key should be kept from the first moment of the bucket,
+// it's the same for every moment of the bucket.
+// If the bucket can't be calculated (see NewBucketer and NewFixedBucketer),
+// ok will be false and p will be a zero-value of Period.
+func (b *Bucketer) Bucket(t time.Time) (key int64, p Period, ok bool) {
+	if !b.ok {
+		return 0, Period{}, false
+	}
+
+	if b.pf == nil && b.width == 0 {
+		if p, ok = b.f.Make(t, b.sc); !ok {
+			return 0, Period{}, false
+		}
+
+		return p.from.t.UnixNano(), p, true
+	}
+
+	if b.tf != nil && b.tf.loc != nil {
+		t = t.In(b.tf.loc)
+	}
+
+	if c, _ := b.last.Load().(*bucket); c != nil &&
+		c.loc == t.Location() && c.p.Contains(t) {
+		return c.key, c.p, true
+	}
+
+	switch {
+	case b.width > 0:
+		p = b.fixed(t)
+	case b.start == nil:
+		var err error
+		if p, err = b.pf.calculate(b.rule, t, b.sc); err != nil {
+			return 0, Period{}, false
+		}
+	default:
+		from, ok := b.tf.calculate(b.start, t)
+		if !ok {
+			return 0, Period{}, false
+		}
+
+		to, ok := b.tf.calculate(b.end, t)
+		if !ok {
+			return 0, Period{}, false
+		}
+
+		p = Period{
+			mode:  b.pf.mode,
+			sc:    b.sc,
+			s:     b.pf.s,
+			tf:    b.pf.tf,
+			pivot: t,
+		}.bound(from, to)
+	}
+
+	key = p.from.t.UnixNano()
+	b.last.Store(&bucket{key: key, p: p, loc: t.Location()})
+
+	return key, p, true
+}
+
+// fixed returns the fixed width bucket of the moment.
+func (b *Bucketer) fixed(t time.Time) Period {
+	n := t.UnixNano() - int64(b.origin)
+	w := int64(b.width)
+
+	start := n / w * w
+	if n%w < 0 {
+		start -= w
+	}
+	start += int64(b.origin)
+
+	from := Time{t: time.Unix(0, start).In(t.Location()), s: DefaultTimeStringer}
+	to := Time{t: from.t.Add(b.width - time.Nanosecond), s: DefaultTimeStringer}
+
+	p := Period{s: &defaultPeriodStringer{}, pivot: t}
+	if b.pf != nil {
+		p.mode, p.s, p.tf = b.pf.mode, b.pf.s, b.pf.tf
+	}
+	if b.tf != nil {
+		from.s, to.s = b.tf.s, b.tf.s
+		to.t, to.precision = roundEnd(to.t, b.tf.precision)
+	}
+
+	return p.bound(from, to)
+}
+
+// snapshotPeriodFactory returns a copy of the settings of the period factory
+// which isn't changed by the later calls of its setters.
+// ok is false if the factory is of another package.
+func snapshotPeriodFactory(f PeriodMaker) (c *unsafePeriodFactory, ok bool) {
+	switch f := f.(type) {
+	case *unsafePeriodFactory:
+		return f.clone(), true
+	case *safePeriodFactory:
+		f.rw.RLock()
+		defer f.rw.RUnlock()
+
+		return snapshotPeriodFactory(f.f)
+	case *cowPeriodFactory:
+		return f.load().clone(), true
+	case *FrozenPeriodFactory:
+		return f.f.clone(), true
+	}
+
+	return nil, false
+}
+
+// snapshotTimeFactory returns a copy of the settings of the time factory
+// which isn't changed by the later calls of its setters and the rules
+// of the shortcuts (nil if a rule isn't found).
+// ok is false if the factory is of another package.
+func snapshotTimeFactory(f TimeFactory, shortcuts ...TimeShortcut) (c *unsafeTimeFactory,
+	rules []TimeRule, ok bool) {
+	switch f := f.(type) {
+	case *unsafeTimeFactory:
+		rules = make([]TimeRule, len(shortcuts))
+		for i, sc := range shortcuts {
+			rules[i], _ = f.rule(sc)
+		}
+
+		return f.clone(), rules, true
+	case *safeTimeFactory:
+		f.rw.RLock()
+		defer f.rw.RUnlock()
+
+		return snapshotTimeFactory(f.f, shortcuts...)
+	case *cowTimeFactory:
+		return snapshotTimeFactory(f.load(), shortcuts...)
+	case frozenTimes:
+		return snapshotTimeFactory(f.f, shortcuts...)
+	}
+
+	return nil, nil, false
+}
@@ -0,0 +1,348 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+// foreignPeriodFactory hides the type of the period factory,
+// so bucketers can't snapshot it.
+type foreignPeriodFactory struct {
+	rdate.PeriodFactory
+}
+
+func TestBucketer_Unit(t *testing.T) {
+	loc := loadLocation(t, "America/Sao_Paulo")
+	fy := rdate.FiscalYear{Month: time.April, Day: 6}
+
+	factories := map[string]rdate.PeriodMaker{
+		"safe": rdate.NewPeriodFactory(),
+		"nonblocking": rdate.NewNonblockingPeriodFactory(
+			rdate.WithIntervalMode(rdate.IntervalHalfOpen)),
+		"copy-on-write": rdate.NewCopyOnWritePeriodFactory(
+			rdate.WithTimeFactory(rdate.NewCopyOnWriteTimeFactory(rdate.WithFiscalYear(fy)))),
+		"frozen": rdate.NewFrozenPeriodFactory(rdate.WithFrozenTimeFactory(
+			rdate.NewFrozenTimeFactory(rdate.WithStartOfWeek(rdate.StartOfWeekSunday)))),
+		"day start": rdate.NewPeriodFactory(rdate.WithTimeFactory(rdate.NewTimeFactory(
+			rdate.WithDayStart(6*time.Hour), rdate.WithPrecision(time.Second)))),
+		"location": rdate.NewPeriodFactory(rdate.WithTimeFactory(
+			rdate.NewTimeFactory(rdate.WithLocation(loc)))),
+		"foreign": &foreignPeriodFactory{rdate.NewPeriodFactory()},
+	}
+
+	units := []rdate.Unit{rdate.UnitDay, rdate.UnitWeek, rdate.UnitMonth,
+		rdate.UnitQuart, rdate.UnitHalfYear, rdate.UnitYear, rdate.UnitISOYear}
+
+	step := 7 * time.Hour
+	if testing.Short() {
+		step = 31 * time.Hour
+	}
+
+	for name, f := range factories {
+		for _, u := range units {
+			t.Run(name+" "+u.String(), func(t *testing.T) {
+				b := rdate.NewBucketer(f, u)
+				sc := rdate.PeriodShortcut("this " + u.String())
+
+				var prevKey int64
+				var prev rdate.Period
+
+				for ts := time.Date(2018, 1, 1, 0, 0, 0, 0, loc); ts.Year() < 2020; ts = ts.Add(step) {
+					key, p, ok := b.Bucket(ts)
+					if !ok {
+						t.Fatalf("%s: expected ok", ts)
+					}
+
+					expected := f.Require(ts, sc)
+					if p.From() != expected.From() || p.To() != expected.To() ||
+						p.String() != expected.String() {
+						t.Fatalf("%s: expected %s, got %s", ts, expected, p)
+					}
+
+					if key != p.From().Time().UnixNano() {
+						t.Fatalf("%s: unexpected key %d", ts, key)
+					}
+
+					if key < prevKey || key == prevKey && p.From() != prev.From() {
+						t.Fatalf("%s: the key %d doesn't follow %d", ts, key, prevKey)
+					}
+
+					prevKey, prev = key, p
+				}
+			})
+		}
+	}
+}
+
+func TestBucketer_Snapshot(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	tf := rdate.NewTimeFactory()
+	f := rdate.NewPeriodFactory(rdate.WithTimeFactory(tf))
+
+	b := rdate.NewBucketer(f, rdate.UnitWeek)
+
+	tf.SetStartOfWeek(rdate.StartOfWeekSunday)
+	f.SetIntervalMode(rdate.IntervalHalfOpen)
+
+	_, p, _ := b.Bucket(pivot)
+	periodEqual(t, p,
+		time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 16, 23, 59, 59, 999999999, time.UTC))
+
+	// the periods of the buckets are calculated by the snapshot too
+	periodEqual(t, p.Next(1),
+		time.Date(2020, 8, 17, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 23, 23, 59, 59, 999999999, time.UTC))
+	if weeks := p.Split(rdate.UnitWeek, rdate.SplitClip); len(weeks) != 1 {
+		t.Errorf("expected 1 week, got %d", len(weeks))
+	}
+
+	_, p, _ = rdate.NewBucketer(f, rdate.UnitWeek).Bucket(pivot)
+	periodEqual(t, p,
+		time.Date(2020, 8, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 16, 0, 0, 0, 0, time.UTC))
+}
+
+// twoDaysPeriodRule replaces "this day" by the day before and the day.
+type twoDaysPeriodRule struct{}
+
+func (r *twoDaysPeriodRule) Calculate(pivot time.Time, tf rdate.TimeFactory) (from, to rdate.Time) {
+	return tf.Require(pivot, rdate.TimeStartOfPrevDay), tf.Require(pivot, rdate.TimeEndOfThisDay)
+}
+
+func (r *twoDaysPeriodRule) Shortcut() rdate.PeriodShortcut { return "this day" }
+
+func TestBucketer_CustomRule(t *testing.T) {
+	pivot := time.Date(2021, 2, 10, 13, 2, 1, 6, time.UTC)
+
+	factories := map[string]rdate.PeriodMaker{
+		"safe":   rdate.NewPeriodFactory(rdate.WithPeriodRules(&twoDaysPeriodRule{})),
+		"frozen": rdate.NewFrozenPeriodFactory(rdate.WithPeriodRules(&twoDaysPeriodRule{})),
+		"foreign": &foreignPeriodFactory{
+			rdate.NewPeriodFactory(rdate.WithPeriodRules(&twoDaysPeriodRule{}))},
+	}
+
+	for name, f := range factories {
+		t.Run(name, func(t *testing.T) {
+			b := rdate.NewBucketer(f, rdate.UnitDay)
+
+			for i := 0; i < 2; i++ { // the second one is cached
+				key, p, ok := b.Bucket(pivot)
+				if !ok {
+					t.Fatal("expected ok")
+				}
+
+				periodEqual(t, p,
+					time.Date(2021, 2, 9, 0, 0, 0, 0, time.UTC),
+					time.Date(2021, 2, 10, 23, 59, 59, 999999999, time.UTC))
+
+				if expected := f.Require(pivot, "this day"); p.String() != expected.String() {
+					t.Errorf("expected %s, got %s", expected, p)
+				}
+
+				if key != p.From().Time().UnixNano() {
+					t.Errorf("unexpected key %d", key)
+				}
+			}
+		})
+	}
+
+	// the rule is snapshotted, so the later rules aren't seen
+	f := rdate.NewPeriodFactory(rdate.WithPeriodRules(&twoDaysPeriodRule{}))
+	b := rdate.NewBucketer(f, rdate.UnitDay)
+	f.Remove("this day")
+
+	_, p, ok := b.Bucket(pivot)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	periodEqual(t, p,
+		time.Date(2021, 2, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 2, 10, 23, 59, 59, 999999999, time.UTC))
+}
+
+func TestBucketer_DefaultRule(t *testing.T) {
+	pivot := time.Date(2021, 2, 10, 13, 2, 1, 6, time.UTC)
+
+	// the rules of the other shortcuts keep the default rule of the unit
+	f := rdate.NewPeriodFactory(rdate.WithPeriodRules(&testPeriodRule{}))
+	f.Extend([]rdate.PeriodRule{&shortcutPeriodRule{"this week"}})
+
+	_, p, ok := rdate.NewBucketer(f, rdate.UnitDay).Bucket(pivot)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	periodEqual(t, p,
+		time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 2, 10, 23, 59, 59, 999999999, time.UTC))
+
+	_, p, ok = rdate.NewBucketer(f, rdate.UnitWeek).Bucket(pivot)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+
+	// the week is replaced, so it's calculated by the rule
+	periodEqual(t, p, pivot, pivot)
+}
+
+func TestBucketer_Fixed(t *testing.T) {
+	testCases := []struct {
+		name   string
+		f      rdate.PeriodFactory
+		width  time.Duration
+		origin time.Duration
+		t      time.Time
+		from   time.Time
+		to     time.Time
+	}{
+		{
+			name:   "15 minutes with origin",
+			f:      rdate.NewPeriodFactory(),
+			width:  15 * time.Minute,
+			origin: 5 * time.Minute,
+			t:      time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC),
+			from:   time.Date(2020, 8, 11, 9, 50, 0, 0, time.UTC),
+			to:     time.Date(2020, 8, 11, 10, 4, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "start of bucket",
+			f:     rdate.NewPeriodFactory(),
+			width: 15 * time.Minute,
+			t:     time.Date(2020, 8, 11, 10, 15, 0, 0, time.UTC),
+			from:  time.Date(2020, 8, 11, 10, 15, 0, 0, time.UTC),
+			to:    time.Date(2020, 8, 11, 10, 29, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "before epoch",
+			f:     rdate.NewPeriodFactory(),
+			width: time.Hour,
+			t:     time.Date(1969, 12, 31, 23, 30, 0, 0, time.UTC),
+			from:  time.Date(1969, 12, 31, 23, 0, 0, 0, time.UTC),
+			to:    time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name: "precision and half-open",
+			f: rdate.NewPeriodFactory(
+				rdate.WithTimeFactory(rdate.NewTimeFactory(rdate.WithPrecision(time.Second))),
+				rdate.WithIntervalMode(rdate.IntervalHalfOpen)),
+			width: 5 * time.Minute,
+			t:     time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC),
+			from:  time.Date(2020, 8, 11, 10, 0, 0, 0, time.UTC),
+			to:    time.Date(2020, 8, 11, 10, 5, 0, 0, time.UTC),
+		},
+		{
+			name:   "half-hour offset",
+			f:      rdate.NewPeriodFactory(),
+			width:  time.Hour,
+			origin: 30 * time.Minute,
+			t:      time.Date(2020, 8, 11, 10, 2, 1, 6, time.FixedZone("IST", 5*3600+1800)),
+			from:   time.Date(2020, 8, 11, 10, 0, 0, 0, time.FixedZone("IST", 5*3600+1800)),
+			to:     time.Date(2020, 8, 11, 10, 59, 59, 999999999, time.FixedZone("IST", 5*3600+1800)),
+		},
+		{
+			name:  "foreign",
+			f:     &foreignPeriodFactory{rdate.NewPeriodFactory()},
+			width: 15 * time.Minute,
+			t:     time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC),
+			from:  time.Date(2020, 8, 11, 10, 0, 0, 0, time.UTC),
+			to:    time.Date(2020, 8, 11, 10, 14, 59, 999999999, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, p, ok := rdate.NewFixedBucketer(tc.f, tc.width, tc.origin).Bucket(tc.t)
+			if !ok {
+				t.Fatalf("expected ok")
+			}
+
+			periodEqual(t, p, tc.from, tc.to)
+			if key != tc.from.UnixNano() {
+				t.Errorf("expected the key %d, got %d", tc.from.UnixNano(), key)
+			}
+			if p.String() == "" {
+				t.Errorf("expected a string")
+			}
+		})
+	}
+}
+
+func TestBucketer_Invalid(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	tf := rdate.NewTimeFactory()
+	tf.Remove(rdate.TimeStartOfThisWeek)
+
+	pf := rdate.NewPeriodFactory()
+	pf.Remove(rdate.PeriodThisMonth)
+
+	testCases := []struct {
+		name string
+		b    *rdate.Bucketer
+	}{
+		{name: "unknown unit", b: rdate.NewBucketer(rdate.NewPeriodFactory(), rdate.Unit(42))},
+		{name: "removed rule", b: rdate.NewBucketer(
+			rdate.NewPeriodFactory(rdate.WithTimeFactory(tf)), rdate.UnitWeek)},
+		{name: "removed period", b: rdate.NewBucketer(pf, rdate.UnitMonth)},
+		{name: "zero width", b: rdate.NewFixedBucketer(rdate.NewPeriodFactory(), 0, 0)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if key, p, ok := tc.b.Bucket(pivot); ok || key != 0 || !p.IsZero() {
+				t.Errorf("expected no bucket, got %d %s", key, p)
+			}
+		})
+	}
+}
+
+func TestBucketer_Concurrent(t *testing.T) {
+	b := rdate.NewBucketer(rdate.NewPeriodFactory(), rdate.UnitDay)
+	start := time.Date(2020, 8, 11, 0, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 1000; j++ {
+				ts := start.Add(time.Duration((i*j)%96) * time.Hour)
+				key, _, _ := b.Bucket(ts)
+				if expected := ts.Truncate(24 * time.Hour).UnixNano(); key != expected {
+					t.Errorf("%s: expected the key %d, got %d", ts, expected, key)
+					return
+				}
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func BenchmarkBucketer_Bucket(b *testing.B) {
+	ts := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	b.Run("make", func(b *testing.B) {
+		f := rdate.NewPeriodFactory()
+		for i := 0; i < b.N; i++ {
+			f.Require(ts.Add(time.Duration(i)*time.Minute), rdate.PeriodThisDay)
+		}
+	})
+	b.Run("bucketer", func(b *testing.B) {
+		bk := rdate.NewBucketer(rdate.NewPeriodFactory(), rdate.UnitDay)
+		for i := 0; i < b.N; i++ {
+			bk.Bucket(ts.Add(time.Duration(i) * time.Minute))
+		}
+	})
+}
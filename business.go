@@ -0,0 +1,288 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// HolidayCalendar reports which days are holidays (non-business days
+// besides the weekend).
+// The date is passed as midnight of the day in the location of the pivot.
+type HolidayCalendar interface {
+	IsHoliday(date time.Time) bool
+}
+
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilDateOf(t time.Time) civilDate {
+	y, m, d := t.Date()
+	return civilDate{year: y, month: m, day: d}
+}
+
+// holidayCalendar is an in-memory HolidayCalendar.
+type holidayCalendar struct {
+	dates map[civilDate]struct{}
+}
+
+// IsHoliday implements the HolidayCalendar IsHoliday method.
+func (c *holidayCalendar) IsHoliday(date time.Time) bool {
+	_, ok := c.dates[civilDateOf(date)]
+	return ok
+}
+
+// NewHolidayCalendar creates an in-memory holiday calendar of the given dates.
+// Only the calendar dates are taken into account, the clock and the location
+// of the values are ignored.
+func NewHolidayCalendar(dates []time.Time) HolidayCalendar {
+	c := &holidayCalendar{dates: make(map[civilDate]struct{}, len(dates))}
+	for _, d := range dates {
+		c.dates[civilDateOf(d)] = struct{}{}
+	}
+
+	return c
+}
+
+// ReadHolidayCalendar creates an in-memory holiday calendar
+// of the dates read from r.
+// Every line contains one date in the format "2006-01-02".
+// Empty lines and everything after "#" are ignored, e.g.
+//
+//	# New Year
+//	2021-01-01
+//	2021-12-25 # Christmas
+func ReadHolidayCalendar(r io.Reader) (HolidayCalendar, error) {
+	var dates []time.Time
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := s.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		d, err := time.Parse("2006-01-02", line)
+		if err != nil {
+			return nil, fmt.Errorf("rdate: holiday calendar line %d: %w", n, err)
+		}
+
+		dates = append(dates, d)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("rdate: holiday calendar: %w", err)
+	}
+
+	return NewHolidayCalendar(dates), nil
+}
+
+// LoadHolidayCalendar creates an in-memory holiday calendar
+// of the dates from the named file (see ReadHolidayCalendar).
+func LoadHolidayCalendar(name string) (HolidayCalendar, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadHolidayCalendar(f)
+}
+
+// businessDays defines which days are business ones.
+type businessDays struct {
+	weekend  [7]bool
+	holidays HolidayCalendar
+}
+
+var defaultBusinessDays = businessDays{
+	weekend: [7]bool{time.Saturday: true, time.Sunday: true},
+}
+
+// maxNonBusinessDays limits the search of a business day, so a calendar
+// without business days doesn't hang the factory.
+const maxNonBusinessDays = 366
+
+func (b businessDays) is(date time.Time) bool {
+	if b.weekend[date.Weekday()] {
+		return false
+	}
+
+	if b.holidays == nil {
+		return true
+	}
+
+	y, m, d := date.Date()
+	return !b.holidays.IsHoliday(startOfDay(y, m, d, date.Location()))
+}
+
+// seek returns the start of the first business day which is found
+// from the day (inclusive) in the direction (1 or -1).
+// The day is given by its start, the days are stepped by the calendar.
+func (b businessDays) seek(day time.Time, direction int, start TimeRule) time.Time {
+	for i := 0; i < maxNonBusinessDays; i++ {
+		if b.is(day) {
+			return day
+		}
+
+		day = start.Calculate(addDays(day, direction))
+	}
+
+	return time.Time{}
+}
+
+// addDays returns the start of the day which is n calendar days away
+// from the date of t. Unlike AddDate it never lands on another date
+// because of a daylight saving time transition.
+func addDays(t time.Time, n int) time.Time {
+	y, m, d := t.Date()
+	return startOfDay(y, m, d+n, t.Location())
+}
+
+// timeRuleBusinessDay calculates the start or the end of the business day
+// which is offset business days away from the day the pivot belongs to.
+type timeRuleBusinessDay struct {
+	sc     TimeShortcut
+	anchor anchor
+	offset int
+	bd     businessDays
+	start  TimeRule
+	end    TimeRule
+}
+
+func (r *timeRuleBusinessDay) Calculate(pivot time.Time) time.Time {
+	direction, n := 1, r.offset
+	if n < 0 {
+		direction, n = -1, -n
+	}
+
+	day := r.start.Calculate(pivot)
+	for ; n > 0 && !day.IsZero(); n-- {
+		day = r.bd.seek(r.start.Calculate(addDays(day, direction)), direction, r.start)
+	}
+
+	if day.IsZero() || r.anchor == anchorStart {
+		return day
+	}
+
+	return r.end.Calculate(day)
+}
+
+func (r *timeRuleBusinessDay) Shortcut() TimeShortcut { return r.sc }
+
+// timeRuleBusinessDayOfUnit calculates the start of the first business day
+// or the end of the last business day of a unit.
+type timeRuleBusinessDayOfUnit struct {
+	sc     TimeShortcut
+	anchor anchor
+	unit   TimeRule
+	bd     businessDays
+	start  TimeRule
+	end    TimeRule
+}
+
+func (r *timeRuleBusinessDayOfUnit) Calculate(pivot time.Time) time.Time {
+	day := r.start.Calculate(r.unit.Calculate(pivot))
+
+	if r.anchor == anchorStart {
+		return r.bd.seek(day, 1, r.start)
+	}
+
+	day = r.bd.seek(day, -1, r.start)
+	if day.IsZero() {
+		return day
+	}
+
+	return r.end.Calculate(day)
+}
+
+func (r *timeRuleBusinessDayOfUnit) Shortcut() TimeShortcut { return r.sc }
+
+// parseBusinessDay builds a rule for a shortcut of a business day:
+//
+//	shortcut = anchor (offset "business day" | unitOffset "business day")
+//	anchor   = "start" | "end"
+//
+// The offset is the one of the grammar with the "day" unit (see parseTimeExpr)
+// where "day" is prefixed by "business", e.g. "start prev business day" or
+// "end 3 business days ago", and it can't be "this".
+// The unitOffset is the one with any other unit, e.g. "start this month business day"
+// is the start of the first business day of the month and "end prev month business day"
+// is the end of the last business day of the previous month.
+func (f *unsafeTimeFactory) parseBusinessDay(sc TimeShortcut) (TimeRule, bool) {
+	words := strings.Fields(string(sc))
+
+	i := -1
+	for j, w := range words {
+		if w == "business" {
+			i = j
+			break
+		}
+	}
+	if i < 0 || i+1 >= len(words) || (words[i+1] != "day" && words[i+1] != "days") {
+		return nil, false
+	}
+
+	start, ok := f.rules[TimeStartOfThisDay]
+	if !ok {
+		return nil, false
+	}
+
+	end, ok := f.rules[TimeEndOfThisDay]
+	if !ok {
+		return nil, false
+	}
+
+	if i == len(words)-2 && words[i+1] == "day" {
+		e, ok := parseTimeExpr(TimeShortcut(strings.Join(words[:i], " ")))
+		if ok && e.unit != unitDay {
+			if e.anchor == anchorAsIs {
+				return nil, false
+			}
+
+			u, ok := f.rule(TimeShortcut(strings.Join(words[:i], " ")))
+			if !ok {
+				return nil, false
+			}
+
+			return &timeRuleBusinessDayOfUnit{
+				sc:     sc,
+				anchor: e.anchor,
+				unit:   u,
+				bd:     f.bd,
+				start:  start,
+				end:    end,
+			}, true
+		}
+	}
+
+	e, ok := parseTimeExpr(TimeShortcut(strings.Join(
+		append(words[:i:i], words[i+1:]...), " ")))
+	if !ok || e.unit != unitDay || e.anchor == anchorAsIs || e.offset == 0 {
+		return nil, false
+	}
+
+	return &timeRuleBusinessDay{
+		sc:     sc,
+		anchor: e.anchor,
+		offset: e.offset,
+		bd:     f.bd,
+		start:  start,
+		end:    end,
+	}, true
+}
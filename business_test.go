@@ -0,0 +1,289 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestTimeFactory_businessDay(t *testing.T) {
+	holidays := rdate.NewHolidayCalendar([]time.Time{
+		time.Date(2021, 8, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 8, 31, 15, 0, 0, 0, time.UTC),
+		time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	testCases := []struct {
+		name     string
+		pivot    time.Time
+		sc       rdate.TimeShortcut
+		weekend  []time.Weekday
+		holidays rdate.HolidayCalendar
+		expected time.Time
+	}{
+		{
+			name:     "TimeStartOfPrevBusinessDay",
+			pivot:    time.Date(2021, 8, 9, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevBusinessDay,
+			expected: time.Date(2021, 8, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfPrevBusinessDay",
+			pivot:    time.Date(2021, 8, 9, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfPrevBusinessDay,
+			expected: time.Date(2021, 8, 6, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfPrevBusinessDay(holiday)",
+			pivot:    time.Date(2021, 8, 9, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevBusinessDay,
+			holidays: holidays,
+			expected: time.Date(2021, 8, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfPrevBusinessDay(Friday and Saturday)",
+			pivot:    time.Date(2021, 8, 8, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevBusinessDay,
+			weekend:  []time.Weekday{time.Friday, time.Saturday},
+			expected: time.Date(2021, 8, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextBusinessDay",
+			pivot:    time.Date(2021, 8, 6, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextBusinessDay,
+			expected: time.Date(2021, 8, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextBusinessDay(no weekend)",
+			pivot:    time.Date(2021, 8, 6, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextBusinessDay,
+			weekend:  []time.Weekday{},
+			expected: time.Date(2021, 8, 7, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "end 3 business days ago",
+			pivot:    time.Date(2021, 8, 9, 10, 2, 1, 6, time.UTC),
+			sc:       "end 3 business days ago",
+			expected: time.Date(2021, 8, 4, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "start in 2 business days",
+			pivot:    time.Date(2021, 8, 6, 10, 2, 1, 6, time.UTC),
+			sc:       "start in 2 business days",
+			expected: time.Date(2021, 8, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisMonthBusinessDay",
+			pivot:    time.Date(2021, 8, 15, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisMonthBusinessDay,
+			expected: time.Date(2021, 8, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisMonthBusinessDay",
+			pivot:    time.Date(2021, 8, 15, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisMonthBusinessDay,
+			expected: time.Date(2021, 8, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisMonthBusinessDay(holiday)",
+			pivot:    time.Date(2021, 8, 15, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisMonthBusinessDay,
+			holidays: holidays,
+			expected: time.Date(2021, 8, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeEndOfPrevMonthBusinessDay",
+			pivot:    time.Date(2021, 8, 15, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfPrevMonthBusinessDay,
+			expected: time.Date(2021, 7, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextMonthBusinessDay(holiday)",
+			pivot:    time.Date(2021, 8, 15, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextMonthBusinessDay,
+			holidays: holidays,
+			expected: time.Date(2021, 9, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "start this quart business day",
+			pivot:    time.Date(2021, 8, 15, 10, 2, 1, 6, time.UTC),
+			sc:       "start this quart business day",
+			expected: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "end 2 months ago business day",
+			pivot:    time.Date(2021, 8, 15, 10, 2, 1, 6, time.UTC),
+			sc:       "end 2 months ago business day",
+			expected: time.Date(2021, 6, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := rdate.NewTimeFactory()
+			if tc.weekend != nil {
+				f.SetWeekend(tc.weekend...)
+			}
+			f.SetHolidayCalendar(tc.holidays)
+
+			actual, ok := f.Make(tc.pivot, tc.sc)
+			if !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			timeEqual(t, actual, tc.expected)
+		})
+	}
+}
+
+func TestTimeFactory_businessDayInvalid(t *testing.T) {
+	testCases := []rdate.TimeShortcut{
+		"start this business day",
+		"as is prev business day",
+		"start business day",
+		"start prev business",
+		"start this month business days",
+		"as is this month business day",
+		"start this month business day ago",
+	}
+
+	f := rdate.NewTimeFactory()
+
+	for _, sc := range testCases {
+		t.Run(string(sc), func(t *testing.T) {
+			if _, ok := f.Make(time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC), sc); ok {
+				t.Errorf("expected not ok but it is")
+			}
+		})
+	}
+}
+
+func TestTimeFactory_SetWeekendInvalid(t *testing.T) {
+	f := rdate.NewTimeFactory()
+	f.SetWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+		time.Thursday, time.Friday, time.Saturday)
+	f.SetWeekend(time.Sunday, 7)
+
+	tm := f.Require(time.Date(2021, 8, 9, 10, 2, 1, 6, time.UTC), rdate.TimeStartOfPrevBusinessDay)
+	timeEqual(t, tm, time.Date(2021, 8, 6, 0, 0, 0, 0, time.UTC))
+}
+
+// everyDayHolidays makes every day a holiday, so there are no business days.
+type everyDayHolidays struct{}
+
+func (c everyDayHolidays) IsHoliday(date time.Time) bool { return true }
+
+func TestTimeFactory_businessDayNotFound(t *testing.T) {
+	pivot := time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	tf := rdate.NewTimeFactory()
+	tf.SetHolidayCalendar(everyDayHolidays{})
+
+	pf := rdate.NewPeriodFactory(rdate.WithTimeFactory(tf))
+
+	for _, sc := range []rdate.TimeShortcut{
+		rdate.TimeStartOfPrevBusinessDay,
+		"end 3 business days ago",
+		"start this month business day",
+		"end this month business day",
+	} {
+		t.Run(string(sc), func(t *testing.T) {
+			if tm, ok := tf.Make(pivot, sc); ok || !tm.IsZero() {
+				t.Errorf("expected not ok and a zero-value, but actual: %v %s", ok, tm)
+			}
+			if _, err := tf.Resolve(pivot, sc); !errors.Is(err, rdate.ErrNoResult) {
+				t.Errorf("expected ErrNoResult but actual: %v", err)
+			}
+		})
+	}
+
+	if p, ok := pf.Make(pivot, rdate.PeriodPrevBusinessDay); ok || !p.IsZero() {
+		t.Errorf("expected not ok and a zero-value, but actual: %v %s", ok, p)
+	}
+	if _, err := pf.Resolve(pivot, rdate.PeriodPrevBusinessDay); !errors.Is(err, rdate.ErrNoResult) {
+		t.Errorf("expected ErrNoResult but actual: %v", err)
+	}
+}
+
+func TestPeriodFactory_businessDay(t *testing.T) {
+	tf := rdate.NewTimeFactory()
+	tf.SetHolidayCalendar(rdate.NewHolidayCalendar([]time.Time{
+		time.Date(2021, 8, 6, 0, 0, 0, 0, time.UTC),
+	}))
+
+	pf := rdate.NewPeriodFactory()
+	pf.SetTimeFactory(tf)
+
+	p := pf.Require(time.Date(2021, 8, 9, 10, 2, 1, 6, time.UTC), rdate.PeriodPrevBusinessDay)
+	periodEqual(t, p,
+		time.Date(2021, 8, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 8, 5, 23, 59, 59, 999999999, time.UTC))
+
+	p = pf.Require(time.Date(2021, 8, 5, 10, 2, 1, 6, time.UTC), rdate.PeriodNextBusinessDay)
+	periodEqual(t, p,
+		time.Date(2021, 8, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 8, 9, 23, 59, 59, 999999999, time.UTC))
+}
+
+func TestReadHolidayCalendar(t *testing.T) {
+	c, err := rdate.ReadHolidayCalendar(strings.NewReader(
+		"# New Year\n2021-01-01\n\n  2021-12-25 # Christmas\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		date     time.Time
+		expected bool
+	}{
+		{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2021, 12, 25, 0, 0, 0, 0, time.Local), true},
+		{time.Date(2021, 12, 24, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		if actual := c.IsHoliday(tc.date); actual != tc.expected {
+			t.Errorf("%s: expected: %t, but actual: %t", tc.date, tc.expected, actual)
+		}
+	}
+
+	_, err = rdate.ReadHolidayCalendar(strings.NewReader("2021-01-01\n2021-13-01\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected an error of the line 2 but actual: %v", err)
+	}
+}
+
+func TestLoadHolidayCalendar(t *testing.T) {
+	file, err := ioutil.TempFile("", "holidays")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString("2021-01-01\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	file.Close()
+
+	c, err := rdate.LoadHolidayCalendar(file.Name())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !c.IsHoliday(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected a holiday but it isn't")
+	}
+
+	if _, err := rdate.LoadHolidayCalendar(file.Name() + ".missing"); err == nil {
+		t.Errorf("expected an error but it isn't")
+	}
+}
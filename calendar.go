@@ -0,0 +1,67 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+// Calendar is a pair of a time factory and a period factory which share
+// the settings of the calendar (e.g. the start of the week, the fiscal year
+// or the location), so "start this week" and the "this week" period
+// always agree.
+//
+// The settings are kept in one place: the time factory of the calendar.
+// The period factory calculates every period by it, so a change
+// of the settings (see Configure) affects both of them at once.
+// The interval mode and the stringer of periods aren't the settings
+// of the calendar, they belong to the period factory (see Periods).
+type Calendar struct {
+	tf *safeTimeFactory
+	pf *safePeriodFactory
+}
+
+// NewCalendar creates a calendar with the default rules and the options
+// applied in the given order. Both of its factories are safe
+// for concurrent use by multiple goroutines.
+func NewCalendar(opts ...TimeOption) *Calendar {
+	tf := newSafeTimeFactory(newUnsafeTimeFactoryWith(opts))
+
+	return &Calendar{
+		tf: tf,
+		pf: newSafePeriodFactory(newUnsafePeriodFactory(defaultPeriodRules,
+			tf, &defaultPeriodStringer{})),
+	}
+}
+
+// Configure applies the options to the settings of the calendar
+// in the given order, e.g.
+//
+//	c.Configure(rdate.WithStartOfWeek(rdate.StartOfWeekSunday))
+//
+// Both factories follow the new settings.
+func (c *Calendar) Configure(opts ...TimeOption) {
+	c.tf.apply(opts)
+}
+
+// Times returns the time factory of the calendar.
+func (c *Calendar) Times() ConfigurableTimeFactory {
+	return c.tf
+}
+
+// Periods returns the period factory of the calendar.
+// Set the interval mode of the periods by it
+// (see ConfigurablePeriodFactory SetIntervalMode).
+// Don't replace its time factory (see PeriodFactory SetTimeFactory),
+// otherwise the factories of the calendar might disagree.
+func (c *Calendar) Periods() ConfigurablePeriodFactory {
+	return c.pf
+}
+
+var defaultCalendar = NewCalendar()
+
+// SetDefaultCalendar sets the factories of the calendar as the default ones
+// (see SetDefaultTimeFactory and SetDefaultPeriodFactory).
+// It's the way to replace the default factories keeping them in agreement.
+func SetDefaultCalendar(c *Calendar) {
+	defaultTimeFactory = c.tf
+	defaultPeriodFactory = c.pf
+}
@@ -0,0 +1,74 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestSetDefaultStartOfWeek(t *testing.T) {
+	rdate.SetDefaultCalendar(rdate.NewCalendar())
+	defer rdate.SetDefaultCalendar(rdate.NewCalendar())
+
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	rdate.SetDefaultStartOfWeek(rdate.StartOfWeekSunday)
+
+	timeEqual(t, rdate.RequireTime(pivot, rdate.TimeStartOfThisWeek),
+		time.Date(2020, 8, 9, 0, 0, 0, 0, time.UTC))
+	periodEqual(t, rdate.RequirePeriod(pivot, rdate.PeriodThisWeek),
+		time.Date(2020, 8, 9, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 15, 23, 59, 59, 999999999, time.UTC))
+}
+
+func TestCalendar_Configure(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	c := rdate.NewCalendar(rdate.WithStartOfWeek(rdate.StartOfWeekSunday))
+
+	periodEqual(t, c.Periods().Require(pivot, rdate.PeriodPrevWeek),
+		time.Date(2020, 8, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 8, 23, 59, 59, 999999999, time.UTC))
+
+	c.Configure(
+		rdate.WithStartOfWeek(rdate.StartOfWeekMonday),
+		rdate.WithFiscalYear(rdate.FiscalYear{Month: time.April, Day: 1}),
+	)
+
+	timeEqual(t, c.Times().Require(pivot, rdate.TimeStartOfPrevWeek),
+		time.Date(2020, 8, 3, 0, 0, 0, 0, time.UTC))
+	periodEqual(t, c.Periods().Require(pivot, rdate.PeriodPrevWeek),
+		time.Date(2020, 8, 3, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 9, 23, 59, 59, 999999999, time.UTC))
+	periodEqual(t, c.Periods().Require(pivot, rdate.PeriodThisYear),
+		time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 3, 31, 23, 59, 59, 999999999, time.UTC))
+
+	// the interval mode is the setting of the period factory
+	c.Periods().SetIntervalMode(rdate.IntervalHalfOpen)
+	c.Configure(rdate.WithStartOfWeek(rdate.StartOfWeekSunday))
+
+	periodEqual(t, c.Periods().Require(pivot, rdate.PeriodPrevWeek),
+		time.Date(2020, 8, 2, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 9, 0, 0, 0, 0, time.UTC))
+}
+
+func TestSetDefaultCalendar(t *testing.T) {
+	defer rdate.SetDefaultCalendar(rdate.NewCalendar())
+
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	c := rdate.NewCalendar(rdate.WithDayStart(6 * time.Hour))
+	rdate.SetDefaultCalendar(c)
+
+	timeEqual(t, rdate.RequireTime(pivot, rdate.TimeStartOfThisDay),
+		time.Date(2020, 8, 10, 6, 0, 0, 0, time.UTC))
+	periodEqual(t, rdate.RequirePeriod(pivot, rdate.PeriodThisDay),
+		time.Date(2020, 8, 10, 6, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 11, 5, 59, 59, 999999999, time.UTC))
+}
@@ -0,0 +1,238 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// cowTimeFactory is a copy-on-write time factory. Readers load the current
+// snapshot atomically and never lock, writers copy the snapshot,
+// change the copy and publish it.
+type cowTimeFactory struct {
+	v  atomic.Value // *unsafeTimeFactory
+	mu sync.Mutex   // serializes writers
+}
+
+// NewCopyOnWriteTimeFactory creates a time factory which is safe for concurrent use
+// by multiple goroutines and whose Make and Require methods never block.
+// Every change (Extend, Remove and the setters) copies the rules and publishes
+// the copy atomically, so it's more expensive than in the factories
+// created by NewTimeFactory.
+//
+// It might be useful when your application is under high load on many cores
+// and your time factory is changed rarely but not only during the init.
+// The options are applied to the factory in the given order.
+func NewCopyOnWriteTimeFactory(opts ...TimeOption) ConfigurableTimeFactory {
+	f := &cowTimeFactory{}
+	f.v.Store(newUnsafeTimeFactoryWith(opts))
+
+	return f
+}
+
+func (f *cowTimeFactory) load() *unsafeTimeFactory {
+	return f.v.Load().(*unsafeTimeFactory)
+}
+
+// update applies the change to a copy of the current snapshot and publishes it.
+func (f *cowTimeFactory) update(change func(c *unsafeTimeFactory)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := f.load().clone()
+	change(c)
+	f.v.Store(c)
+}
+
+// Make implements the TimeFactory Make method.
+func (f *cowTimeFactory) Make(pivot time.Time, sc TimeShortcut) (t Time, ok bool) {
+	return f.load().Make(pivot, sc)
+}
+
+// Require implements the TimeFactory Require method.
+func (f *cowTimeFactory) Require(pivot time.Time, sc TimeShortcut) Time {
+	return f.load().Require(pivot, sc)
+}
+
+// Resolve implements the TimeResolver Resolve method.
+func (f *cowTimeFactory) Resolve(pivot time.Time, sc TimeShortcut) (Time, error) {
+	return f.load().Resolve(pivot, sc)
+}
+
+// MustResolve implements the TimeResolver MustResolve method.
+func (f *cowTimeFactory) MustResolve(pivot time.Time, sc TimeShortcut) Time {
+	return f.load().MustResolve(pivot, sc)
+}
+
+// Rules implements the RuleLister Rules method.
+func (f *cowTimeFactory) Rules() []RuleInfo {
+	return f.load().Rules()
+}
+
+// Clone implements the ConfigurableTimeFactory Clone method.
+func (f *cowTimeFactory) Clone() ConfigurableTimeFactory {
+	c := &cowTimeFactory{}
+	c.v.Store(f.load().clone())
+
+	return c
+}
+
+// location returns the location pivots are converted into.
+func (f *cowTimeFactory) location() *time.Location {
+	return f.load().loc
+}
+
+// Extend implements the TimeFactory Extend method.
+func (f *cowTimeFactory) Extend(rules []TimeRule) {
+	f.update(func(c *unsafeTimeFactory) { c.Extend(rules) })
+}
+
+// Remove implements the ConfigurableTimeFactory Remove method.
+func (f *cowTimeFactory) Remove(shortcuts ...TimeShortcut) {
+	f.update(func(c *unsafeTimeFactory) { c.Remove(shortcuts...) })
+}
+
+// SetStartOfWeek implements the TimeFactory SetStartOfWeek method.
+func (f *cowTimeFactory) SetStartOfWeek(s StartOfWeek) {
+	f.update(func(c *unsafeTimeFactory) { c.SetStartOfWeek(s) })
+}
+
+// SetFiscalYear implements the ConfigurableTimeFactory SetFiscalYear method.
+func (f *cowTimeFactory) SetFiscalYear(fy FiscalYear) {
+	f.update(func(c *unsafeTimeFactory) { c.SetFiscalYear(fy) })
+}
+
+// SetWeekend implements the ConfigurableTimeFactory SetWeekend method.
+func (f *cowTimeFactory) SetWeekend(days ...time.Weekday) {
+	f.update(func(c *unsafeTimeFactory) { c.SetWeekend(days...) })
+}
+
+// SetHolidayCalendar implements the ConfigurableTimeFactory SetHolidayCalendar method.
+func (f *cowTimeFactory) SetHolidayCalendar(hc HolidayCalendar) {
+	f.update(func(c *unsafeTimeFactory) { c.SetHolidayCalendar(hc) })
+}
+
+// SetDayStart implements the ConfigurableTimeFactory SetDayStart method.
+func (f *cowTimeFactory) SetDayStart(offset time.Duration) {
+	f.update(func(c *unsafeTimeFactory) { c.SetDayStart(offset) })
+}
+
+// SetPrecision implements the ConfigurableTimeFactory SetPrecision method.
+func (f *cowTimeFactory) SetPrecision(p time.Duration) {
+	f.update(func(c *unsafeTimeFactory) { c.SetPrecision(p) })
+}
+
+// SetLocation implements the ConfigurableTimeFactory SetLocation method.
+func (f *cowTimeFactory) SetLocation(loc *time.Location) {
+	f.update(func(c *unsafeTimeFactory) { c.SetLocation(loc) })
+}
+
+// SetStringer implements the TimeFactory SetStringer method.
+func (f *cowTimeFactory) SetStringer(s TimeStringer) {
+	f.update(func(c *unsafeTimeFactory) { c.SetStringer(s) })
+}
+
+// cowPeriodFactory is a copy-on-write period factory (see cowTimeFactory).
+type cowPeriodFactory struct {
+	v  atomic.Value // *unsafePeriodFactory
+	mu sync.Mutex   // serializes writers
+}
+
+// NewCopyOnWritePeriodFactory creates a period factory which is safe
+// for concurrent use by multiple goroutines and whose Make and Require methods
+// never block (see NewCopyOnWriteTimeFactory).
+// The default time factory of it is NewCopyOnWriteTimeFactory().
+// The options are applied to the factory in the given order.
+func NewCopyOnWritePeriodFactory(opts ...PeriodOption) ConfigurablePeriodFactory {
+	pf := newUnsafePeriodFactory(defaultPeriodRules,
+		NewCopyOnWriteTimeFactory(), &defaultPeriodStringer{})
+	for _, opt := range opts {
+		opt(pf)
+	}
+
+	f := &cowPeriodFactory{}
+	f.v.Store(pf)
+
+	return f
+}
+
+func (f *cowPeriodFactory) load() *unsafePeriodFactory {
+	return f.v.Load().(*unsafePeriodFactory)
+}
+
+// update applies the change to a copy of the current snapshot and publishes it.
+func (f *cowPeriodFactory) update(change func(c *unsafePeriodFactory)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := f.load().clone()
+	change(c)
+	f.v.Store(c)
+}
+
+// Make implements the PeriodFactory Make method.
+func (f *cowPeriodFactory) Make(pivot time.Time, sc PeriodShortcut) (p Period, ok bool) {
+	return f.load().Make(pivot, sc)
+}
+
+// Require implements the PeriodFactory Require method.
+func (f *cowPeriodFactory) Require(pivot time.Time, sc PeriodShortcut) Period {
+	return f.load().Require(pivot, sc)
+}
+
+// Resolve implements the PeriodResolver Resolve method.
+func (f *cowPeriodFactory) Resolve(pivot time.Time, sc PeriodShortcut) (Period, error) {
+	return f.load().Resolve(pivot, sc)
+}
+
+// MustResolve implements the PeriodResolver MustResolve method.
+func (f *cowPeriodFactory) MustResolve(pivot time.Time, sc PeriodShortcut) Period {
+	return f.load().MustResolve(pivot, sc)
+}
+
+// Rules implements the RuleLister Rules method.
+func (f *cowPeriodFactory) Rules() []RuleInfo {
+	return f.load().Rules()
+}
+
+// Clone implements the ConfigurablePeriodFactory Clone method.
+func (f *cowPeriodFactory) Clone() ConfigurablePeriodFactory {
+	c := &cowPeriodFactory{}
+	c.v.Store(f.load().clone())
+
+	return c
+}
+
+// Extend implements the PeriodFactory Extend method.
+func (f *cowPeriodFactory) Extend(rules []PeriodRule) {
+	f.update(func(c *unsafePeriodFactory) { c.Extend(rules) })
+}
+
+// Remove implements the ConfigurablePeriodFactory Remove method.
+func (f *cowPeriodFactory) Remove(shortcuts ...PeriodShortcut) {
+	f.update(func(c *unsafePeriodFactory) { c.Remove(shortcuts...) })
+}
+
+// SetTimeFactory implements the PeriodFactory SetTimeFactory method.
+func (f *cowPeriodFactory) SetTimeFactory(tf TimeFactory) {
+	f.update(func(c *unsafePeriodFactory) { c.SetTimeFactory(tf) })
+}
+
+// SetLocation implements the ConfigurablePeriodFactory SetLocation method.
+func (f *cowPeriodFactory) SetLocation(loc *time.Location) {
+	f.update(func(c *unsafePeriodFactory) { c.SetLocation(loc) })
+}
+
+// SetIntervalMode implements the ConfigurablePeriodFactory SetIntervalMode method.
+func (f *cowPeriodFactory) SetIntervalMode(m IntervalMode) {
+	f.update(func(c *unsafePeriodFactory) { c.SetIntervalMode(m) })
+}
+
+// SetStringer implements the PeriodFactory SetStringer method.
+func (f *cowPeriodFactory) SetStringer(s PeriodStringer) {
+	f.update(func(c *unsafePeriodFactory) { c.SetStringer(s) })
+}
@@ -0,0 +1,156 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestCopyOnWriteTimeFactory(t *testing.T) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewCopyOnWriteTimeFactory(rdate.WithStartOfWeek(rdate.StartOfWeekSunday))
+
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisWeek),
+		time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC))
+
+	f.SetStartOfWeek(rdate.StartOfWeekMonday)
+	f.Extend([]rdate.TimeRule{&testTimeRule{}})
+
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisWeek),
+		time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC))
+	if _, err := f.Resolve(pivot, "my test time"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	c := f.Clone()
+	f.Remove("my test time")
+
+	if _, ok := f.Make(pivot, "my test time"); ok {
+		t.Errorf("expected ok = false but it's true")
+	}
+	if _, ok := c.Make(pivot, "my test time"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+}
+
+func TestCopyOnWritePeriodFactory(t *testing.T) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewCopyOnWritePeriodFactory(rdate.WithPeriodRules(&testPeriodRule{}))
+
+	if _, ok := f.Make(pivot, "my test period"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+
+	f.SetTimeFactory(rdate.NewTimeFactory(rdate.WithStartOfWeek(rdate.StartOfWeekSunday)))
+	f.SetStringer(&testPeriodStringer{})
+	f.Remove("my test period")
+
+	p := f.MustResolve(pivot, rdate.PeriodThisWeek)
+	periodEqual(t, p,
+		time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 11, 23, 59, 59, 999999999, time.UTC))
+	if p.String() != "test period stringer" {
+		t.Errorf("expected: 'test period stringer', but actual: '%s'", p.String())
+	}
+	if _, ok := f.Make(pivot, "my test period"); ok {
+		t.Errorf("expected ok = false but it's true")
+	}
+}
+
+func TestCopyOnWritePeriodFactory_concurrentUse(t *testing.T) {
+	f := rdate.NewCopyOnWritePeriodFactory()
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				periodEqual(t, f.Require(pivot, rdate.PeriodPrevMonth),
+					time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC),
+					time.Date(2020, 6, 30, 23, 59, 59, 999999999, time.UTC))
+			}
+		}()
+	}
+
+	for j := 0; j < 100; j++ {
+		f.Extend([]rdate.PeriodRule{&testPeriodRule{}})
+		f.Remove("my test period")
+	}
+
+	wg.Wait()
+}
+
+func benchmarkTimeFactory(b *testing.B, f rdate.TimeFactory) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			f.Require(pivot, rdate.TimeStartOfPrevMonth)
+		}
+	})
+}
+
+func BenchmarkTimeFactory_Require(b *testing.B) {
+	b.Run("safe", func(b *testing.B) {
+		benchmarkTimeFactory(b, rdate.NewTimeFactory())
+	})
+	b.Run("nonblocking", func(b *testing.B) {
+		benchmarkTimeFactory(b, rdate.NewNonblockingTimeFactory())
+	})
+	b.Run("copy-on-write", func(b *testing.B) {
+		benchmarkTimeFactory(b, rdate.NewCopyOnWriteTimeFactory())
+	})
+}
+
+func benchmarkPeriodFactory(b *testing.B, f rdate.PeriodFactory) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			f.Require(pivot, rdate.PeriodPrevMonth)
+		}
+	})
+}
+
+func BenchmarkPeriodFactory_Require(b *testing.B) {
+	b.Run("safe", func(b *testing.B) {
+		benchmarkPeriodFactory(b, rdate.NewPeriodFactory(
+			rdate.WithTimeFactory(rdate.NewTimeFactory())))
+	})
+	b.Run("nonblocking", func(b *testing.B) {
+		benchmarkPeriodFactory(b, rdate.NewNonblockingPeriodFactory())
+	})
+	b.Run("copy-on-write", func(b *testing.B) {
+		benchmarkPeriodFactory(b, rdate.NewCopyOnWritePeriodFactory())
+	})
+}
+
+func BenchmarkTimeFactory_Extend(b *testing.B) {
+	rules := []rdate.TimeRule{&testTimeRule{}}
+
+	b.Run("safe", func(b *testing.B) {
+		f := rdate.NewTimeFactory()
+		for i := 0; i < b.N; i++ {
+			f.Extend(rules)
+		}
+	})
+	b.Run("copy-on-write", func(b *testing.B) {
+		f := rdate.NewCopyOnWriteTimeFactory()
+		for i := 0; i < b.N; i++ {
+			f.Extend(rules)
+		}
+	})
+}
@@ -0,0 +1,249 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+// dstZones switch daylight saving time at midnight (Sao_Paulo, Beirut, Havana,
+// Santiago), by half an hour (Lord_Howe), have moved across the date line
+// (Apia) or are just the usual ones.
+var dstZones = []string{
+	"America/Sao_Paulo",
+	"Asia/Beirut",
+	"America/Havana",
+	"America/Santiago",
+	"Australia/Lord_Howe",
+	"Pacific/Apia",
+	"America/New_York",
+	"Europe/London",
+}
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("tzdata is unavailable: %v", err)
+	}
+
+	return loc
+}
+
+func TestTimeFactory_DST(t *testing.T) {
+	testCases := []struct {
+		zone     string
+		pivot    time.Time
+		sc       rdate.TimeShortcut
+		expected time.Time
+	}{
+		{
+			// There is no midnight on 2018-11-04, clocks jump to 01:00.
+			zone:     "America/Sao_Paulo",
+			pivot:    time.Date(2018, 11, 4, 15, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeStartOfThisDay,
+			expected: time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			zone:     "America/Sao_Paulo",
+			pivot:    time.Date(2018, 11, 4, 15, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeEndOfPrevDay,
+			expected: time.Date(2018, 11, 4, 2, 59, 59, 999999999, time.UTC),
+		},
+		{
+			zone:     "America/Sao_Paulo",
+			pivot:    time.Date(2018, 11, 1, 15, 0, 0, 0, time.UTC),
+			sc:       "start in 3 days",
+			expected: time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			// The hour before midnight of 2019-02-16 occurs twice.
+			zone:     "America/Sao_Paulo",
+			pivot:    time.Date(2019, 2, 16, 15, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeEndOfThisDay,
+			expected: time.Date(2019, 2, 17, 2, 59, 59, 999999999, time.UTC),
+		},
+		{
+			zone:     "Asia/Beirut",
+			pivot:    time.Date(2020, 3, 29, 12, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeStartOfThisDay,
+			expected: time.Date(2020, 3, 28, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			// Midnight of 2020-11-01 occurs twice, the day starts at the first one.
+			zone:     "America/Havana",
+			pivot:    time.Date(2020, 11, 1, 12, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeStartOfThisDay,
+			expected: time.Date(2020, 11, 1, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			zone:     "America/Havana",
+			pivot:    time.Date(2020, 11, 1, 12, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeEndOfPrevDay,
+			expected: time.Date(2020, 11, 1, 3, 59, 59, 999999999, time.UTC),
+		},
+		{
+			zone:     "America/Havana",
+			pivot:    time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeStartOfThisWeek,
+			expected: time.Date(2020, 3, 9, 4, 0, 0, 0, time.UTC),
+		},
+		{
+			// 2011-12-30 was skipped, it starts together with 2011-12-31.
+			zone:     "Pacific/Apia",
+			pivot:    time.Date(2011, 12, 29, 20, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeStartOfNextDay,
+			expected: time.Date(2011, 12, 30, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	f := rdate.NewTimeFactory()
+
+	for _, tc := range testCases {
+		t.Run(tc.zone+" "+string(tc.sc), func(t *testing.T) {
+			loc := loadLocation(t, tc.zone)
+
+			tm := f.MustResolve(tc.pivot.In(loc), tc.sc)
+			if !tm.Time().Equal(tc.expected) {
+				t.Errorf("expected: %v, got: %v", tc.expected.In(loc), tm.Time())
+			}
+		})
+	}
+}
+
+func TestTimeFactory_DSTInvariants(t *testing.T) {
+	factories := []struct {
+		name  string
+		f     rdate.ConfigurableTimeFactory
+		units []string
+	}{
+		{
+			name:  "default",
+			f:     rdate.NewTimeFactory(),
+			units: []string{"day", "week", "month", "quart", "half year", "year", "iso year"},
+		},
+		{
+			name:  "sunday",
+			f:     rdate.NewTimeFactory(rdate.WithStartOfWeek(rdate.StartOfWeekSunday)),
+			units: []string{"week"},
+		},
+		{
+			name: "fiscal",
+			f: rdate.NewTimeFactory(
+				rdate.WithFiscalYear(rdate.FiscalYear{Month: time.April, Day: 6})),
+			units: []string{"quart", "half year", "year"},
+		},
+		{
+			name:  "retail",
+			f:     newRetailTimeFactory(t, rdate.NRFCalendar),
+			units: []string{"week", "month", "quart", "half year", "year"},
+		},
+	}
+
+	step := 11 * time.Hour
+	if testing.Short() {
+		step = 31 * time.Hour
+	}
+
+	for _, zone := range dstZones {
+		t.Run(zone, func(t *testing.T) {
+			loc := loadLocation(t, zone)
+
+			for _, tf := range factories {
+				from := time.Date(2012, 1, 1, 0, 0, 0, 0, loc)
+				to := time.Date(2021, 12, 31, 0, 0, 0, 0, loc)
+
+				for pivot := from; pivot.Before(to); pivot = pivot.Add(step) {
+					checkUnits(t, tf.name, tf.f, pivot, tf.units)
+				}
+			}
+
+			rules := rdate.NewTimeFactory().Rules()
+			f := rdate.NewTimeFactory()
+			from := time.Date(2017, 1, 1, 0, 0, 0, 0, loc)
+			to := time.Date(2021, 12, 31, 0, 0, 0, 0, loc)
+
+			for pivot := from; pivot.Before(to); pivot = pivot.Add(5 * step) {
+				checkRules(t, f, pivot, rules)
+			}
+		})
+	}
+}
+
+// checkUnits checks that the units are contiguous, contain the pivot
+// and are bounded by the starts and the ends of days.
+func checkUnits(t *testing.T, name string, f rdate.ConfigurableTimeFactory, pivot time.Time, units []string) {
+	t.Helper()
+
+	for _, u := range units {
+		get := func(anchor, offset string) time.Time {
+			return f.MustResolve(pivot, rdate.TimeShortcut(anchor+" "+offset+" "+u)).Time()
+		}
+
+		startPrev, endPrev := get("start", "prev"), get("end", "prev")
+		startThis, endThis := get("start", "this"), get("end", "this")
+		startNext, endNext := get("start", "next"), get("end", "next")
+
+		for _, ts := range []time.Time{startPrev, startThis, startNext} {
+			if !isDayStart(ts) {
+				t.Fatalf("%s, %s, pivot %v: %v isn't a start of a day", name, u, pivot, ts)
+			}
+		}
+
+		for _, ts := range []time.Time{endPrev, endThis, endNext} {
+			if !isDayStart(ts.Add(time.Nanosecond)) {
+				t.Fatalf("%s, %s, pivot %v: %v isn't an end of a day", name, u, pivot, ts)
+			}
+		}
+
+		if pivot.Before(startThis) || pivot.After(endThis) {
+			t.Fatalf("%s, %s: pivot %v is out of [%v, %v]", name, u, pivot, startThis, endThis)
+		}
+
+		if !endPrev.Add(time.Nanosecond).Equal(startThis) ||
+			!endThis.Add(time.Nanosecond).Equal(startNext) {
+			t.Fatalf("%s, %s, pivot %v: units aren't contiguous: [%v, %v] [%v, %v] [%v, %v]",
+				name, u, pivot, startPrev, endPrev, startThis, endThis, startNext, endNext)
+		}
+
+		if !startPrev.Before(endPrev) || !startNext.Before(endNext) {
+			t.Fatalf("%s, %s, pivot %v: empty units: [%v, %v] [%v, %v]",
+				name, u, pivot, startPrev, endPrev, startNext, endNext)
+		}
+	}
+}
+
+// checkRules checks that every rule which calculates a start or an end
+// returns a start or an end of a day.
+func checkRules(t *testing.T, f rdate.ConfigurableTimeFactory, pivot time.Time, rules []rdate.RuleInfo) {
+	t.Helper()
+
+	for _, r := range rules {
+		sc := rdate.TimeShortcut(r.Shortcut)
+		ts := f.Require(pivot, sc).Time()
+
+		switch {
+		case ts.IsZero():
+			t.Fatalf("%s, pivot %v: zero time", sc, pivot)
+		case strings.HasPrefix(string(sc), "start ") && !isDayStart(ts):
+			t.Fatalf("%s, pivot %v: %v isn't a start of a day", sc, pivot, ts)
+		case strings.HasPrefix(string(sc), "end ") && !isDayStart(ts.Add(time.Nanosecond)):
+			t.Fatalf("%s, pivot %v: %v isn't an end of a day", sc, pivot, ts)
+		}
+	}
+}
+
+// isDayStart reports whether ts is the first moment of its local date.
+func isDayStart(ts time.Time) bool {
+	y, m, d := ts.Date()
+	py, pm, pd := ts.Add(-time.Nanosecond).Date()
+
+	return py != y || pm != m || pd != d
+}
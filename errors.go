@@ -0,0 +1,134 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoResult is wrapped by the errors of Resolve methods when the rule
+// of the shortcut is found, but it can't calculate the result
+// for the pivot, e.g. a period rule relies on a removed time shortcut.
+var ErrNoResult = errors.New("rdate: no result")
+
+// noResultError returns the error of the shortcut which has no result
+// for the pivot, it wraps ErrNoResult.
+func noResultError(sc string, pivot time.Time) error {
+	return fmt.Errorf("rdate: the shortcut %q has no result for %s: %w", sc, pivot, ErrNoResult)
+}
+
+// UnknownShortcutError is returned when there is no rule registered with
+// the shortcut and the shortcut doesn't match the grammar.
+// Suggestions contains the nearest registered shortcuts by edit distance
+// (up to maxSuggestions), it's empty if there are no close enough ones.
+type UnknownShortcutError struct {
+	Shortcut    string
+	Suggestions []string
+}
+
+func (e *UnknownShortcutError) Error() string {
+	msg := fmt.Sprintf("rdate: unknown shortcut %q", e.Shortcut)
+
+	switch n := len(e.Suggestions); n {
+	case 0:
+		return msg
+	case 1:
+		return fmt.Sprintf("%s, did you mean %q?", msg, e.Suggestions[0])
+	default:
+		quoted := make([]string, n)
+		for i, s := range e.Suggestions {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+
+		return fmt.Sprintf("%s, did you mean %s or %s?", msg,
+			strings.Join(quoted[:n-1], ", "), quoted[n-1])
+	}
+}
+
+// maxSuggestions is the max number of suggestions of UnknownShortcutError.
+const maxSuggestions = 3
+
+// newUnknownShortcutError creates an error of the unknown shortcut
+// with the nearest of the known shortcuts as suggestions.
+// A known shortcut is close enough if its edit distance to the unknown one
+// is at most a third of the unknown shortcut length (but not less than 2).
+func newUnknownShortcutError(sc string, known []string) *UnknownShortcutError {
+	max := len([]rune(sc)) / 3
+	if max < 2 {
+		max = 2
+	}
+
+	type candidate struct {
+		sc   string
+		dist int
+	}
+
+	var candidates []candidate
+	for _, k := range known {
+		if d := editDistance(sc, k); d <= max {
+			candidates = append(candidates, candidate{sc: k, dist: d})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].dist != candidates[j].dist {
+			return candidates[i].dist < candidates[j].dist
+		}
+
+		return candidates[i].sc < candidates[j].sc
+	})
+
+	if len(candidates) > maxSuggestions {
+		candidates = candidates[:maxSuggestions]
+	}
+
+	e := &UnknownShortcutError{Shortcut: sc}
+	for _, c := range candidates {
+		e.Suggestions = append(e.Suggestions, c.sc)
+	}
+
+	return e
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func minInt(v int, vs ...int) int {
+	for _, x := range vs {
+		if x < v {
+			v = x
+		}
+	}
+
+	return v
+}
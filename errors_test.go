@@ -0,0 +1,200 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestTimeFactory_Resolve(t *testing.T) {
+	f := rdate.NewTimeFactory()
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	tm, err := f.Resolve(pivot, "start 3 months ago")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	timeEqual(t, tm, time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	tm, err = f.Resolve(pivot, "start prev quater")
+	if !tm.IsZero() {
+		t.Errorf("expected tm has a zero-value but doesn't")
+	}
+
+	var e *rdate.UnknownShortcutError
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *UnknownShortcutError but actual: %v", err)
+	}
+	if e.Shortcut != "start prev quater" {
+		t.Errorf("expected: 'start prev quater', but actual: '%s'", e.Shortcut)
+	}
+	if len(e.Suggestions) == 0 || e.Suggestions[0] != "start prev quart" {
+		t.Errorf("expected 'start prev quart' first, but actual: %v", e.Suggestions)
+	}
+}
+
+func TestPeriodFactory_Resolve(t *testing.T) {
+	testCases := []struct {
+		sc          rdate.PeriodShortcut
+		suggestions []string
+		msg         string
+	}{
+		{
+			sc:          "prev quater",
+			suggestions: []string{"prev quart"},
+			msg:         `rdate: unknown shortcut "prev quater", did you mean "prev quart"?`,
+		},
+		{
+			sc:          "this yearr",
+			suggestions: []string{"this year", "this quart"},
+			msg: `rdate: unknown shortcut "this yearr", ` +
+				`did you mean "this year" or "this quart"?`,
+		},
+		{
+			sc:  "the beginning of time",
+			msg: `rdate: unknown shortcut "the beginning of time"`,
+		},
+	}
+
+	f := rdate.NewPeriodFactory()
+
+	for _, tc := range testCases {
+		t.Run(string(tc.sc), func(t *testing.T) {
+			p, err := f.Resolve(time.Now(), tc.sc)
+			if !p.IsZero() {
+				t.Errorf("expected p has a zero-value but doesn't")
+			}
+
+			var e *rdate.UnknownShortcutError
+			if !errors.As(err, &e) {
+				t.Fatalf("expected *UnknownShortcutError but actual: %v", err)
+			}
+			if !reflect.DeepEqual(e.Suggestions, tc.suggestions) {
+				t.Errorf("expected: %v, but actual: %v", tc.suggestions, e.Suggestions)
+			}
+			if err.Error() != tc.msg {
+				t.Errorf("expected: '%s', but actual: '%s'", tc.msg, err.Error())
+			}
+		})
+	}
+}
+
+func TestPeriodFactory_Resolve_removedTime(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	tf := rdate.NewTimeFactory()
+	tf.Remove(rdate.TimeStartOfThisMonth, rdate.TimeAsIs)
+
+	f := rdate.NewPeriodFactory(rdate.WithTimeFactory(tf),
+		rdate.WithPeriodRules(&shortcutPeriodRule{"as is"}))
+
+	testCases := []struct {
+		sc rdate.PeriodShortcut
+		// removed is the time shortcut of the error,
+		// the error wraps ErrNoResult if it's empty.
+		removed string
+	}{
+		{sc: "prev 2 months", removed: "start 2 months ago"},
+		{sc: "month to date", removed: "start this month"},
+		{sc: rdate.PeriodThisMonth},
+		{sc: "as is"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.sc), func(t *testing.T) {
+			if p, ok := f.Make(pivot, tc.sc); ok || !p.IsZero() {
+				t.Errorf("expected ok = false and a zero-value, but actual: %v %s", ok, p)
+			}
+
+			_, err := f.Resolve(pivot, tc.sc)
+			if tc.removed == "" {
+				if !errors.Is(err, rdate.ErrNoResult) {
+					t.Errorf("expected ErrNoResult but actual: %v", err)
+				}
+				return
+			}
+
+			var e *rdate.UnknownShortcutError
+			if !errors.As(err, &e) {
+				t.Fatalf("expected *UnknownShortcutError but actual: %v", err)
+			}
+			if e.Shortcut != tc.removed {
+				t.Errorf("expected: '%s', but actual: '%s'", tc.removed, e.Shortcut)
+			}
+		})
+	}
+}
+
+func TestMustResolve(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	timeEqual(t, rdate.MustResolveTime(pivot, rdate.TimeStartOfPrevDay),
+		time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC))
+	periodEqual(t, rdate.MustResolvePeriod(pivot, rdate.PeriodPrevDay),
+		time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 10, 23, 59, 59, 999999999, time.UTC))
+
+	if _, err := rdate.ResolveTime(pivot, "prev quater"); err == nil {
+		t.Errorf("expected an error but it isn't")
+	}
+	if _, err := rdate.ResolvePeriod(pivot, "prev quater"); err == nil {
+		t.Errorf("expected an error but it isn't")
+	}
+
+	mustPanic(t, func() { rdate.NewTimeFactory().MustResolve(pivot, "prev quater") })
+	mustPanic(t, func() { rdate.NewPeriodFactory().MustResolve(pivot, "prev quater") })
+	mustPanic(t, func() { rdate.NewNonblockingTimeFactory().MustResolve(pivot, "prev quater") })
+	mustPanic(t, func() { rdate.NewNonblockingPeriodFactory().MustResolve(pivot, "prev quater") })
+}
+
+// foreignTimeFactory implements only TimeFactory, so it hides
+// the optional interfaces of the wrapped factory.
+type foreignTimeFactory struct {
+	rdate.TimeFactory
+}
+
+func TestResolve_foreignFactories(t *testing.T) {
+	defer rdate.SetDefaultCalendar(rdate.NewCalendar())
+
+	rdate.SetDefaultTimeFactory(&foreignTimeFactory{rdate.NewTimeFactory()})
+	rdate.SetDefaultPeriodFactory(&foreignPeriodFactory{rdate.NewPeriodFactory()})
+
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	timeEqual(t, rdate.MustResolveTime(pivot, rdate.TimeStartOfPrevDay),
+		time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC))
+	periodEqual(t, rdate.MustResolvePeriod(pivot, rdate.PeriodPrevDay),
+		time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 10, 23, 59, 59, 999999999, time.UTC))
+
+	var e *rdate.UnknownShortcutError
+	if _, err := rdate.ResolveTime(pivot, "start prev quater"); !errors.As(err, &e) {
+		t.Errorf("expected *UnknownShortcutError but actual: %v", err)
+	} else if len(e.Suggestions) != 0 {
+		t.Errorf("expected no suggestions, but actual: %v", e.Suggestions)
+	}
+	if _, err := rdate.ResolvePeriod(pivot, "prev quater"); !errors.As(err, &e) {
+		t.Errorf("expected *UnknownShortcutError but actual: %v", err)
+	}
+	mustPanic(t, func() { rdate.MustResolveTime(pivot, "start prev quater") })
+	mustPanic(t, func() { rdate.MustResolvePeriod(pivot, "prev quater") })
+}
+
+func mustPanic(t *testing.T, fn func()) {
+	t.Helper()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Errorf("expected a panic but it isn't")
+		}
+	}()
+
+	fn()
+}
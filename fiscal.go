@@ -0,0 +1,162 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"fmt"
+	"time"
+)
+
+// FiscalYear is the start of a fiscal year, e.g. April 1
+// or April 6 (UK tax year).
+// The day must be in the range 1-28.
+type FiscalYear struct {
+	Month time.Month
+	Day   int
+}
+
+// CalendarYear is a fiscal year which matches the calendar one.
+var CalendarYear = FiscalYear{Month: time.January, Day: 1}
+
+func (fy FiscalYear) valid() bool {
+	return fy.Month >= time.January && fy.Month <= time.December &&
+		fy.Day >= 1 && fy.Day <= 28
+}
+
+// Year returns the fiscal year t belongs to.
+// A fiscal year is named by the calendar year in which it ends,
+// e.g. the fiscal year from April 1, 2020 to March 31, 2021 is 2021.
+func (fy FiscalYear) Year(t time.Time) int {
+	year, _ := fy.months(t)
+	if fy == CalendarYear {
+		return year
+	}
+
+	return year + 1
+}
+
+// Quart returns the number (1-4) of the fiscal quart t belongs to.
+func (fy FiscalYear) Quart(t time.Time) int {
+	_, n := fy.months(t)
+	return n/3 + 1
+}
+
+// HalfYear returns the number (1-2) of the fiscal half year t belongs to.
+func (fy FiscalYear) HalfYear(t time.Time) int {
+	_, n := fy.months(t)
+	return n/6 + 1
+}
+
+// YearLabel returns the label of the fiscal year t belongs to, e.g. "FY2021".
+func (fy FiscalYear) YearLabel(t time.Time) string {
+	return fmt.Sprintf("FY%d", fy.Year(t))
+}
+
+// QuartLabel returns the label of the fiscal quart t belongs to, e.g. "FY2021 Q3".
+func (fy FiscalYear) QuartLabel(t time.Time) string {
+	return fmt.Sprintf("FY%d Q%d", fy.Year(t), fy.Quart(t))
+}
+
+// HalfYearLabel returns the label of the fiscal half year t belongs to,
+// e.g. "FY2021 H1".
+func (fy FiscalYear) HalfYearLabel(t time.Time) string {
+	return fmt.Sprintf("FY%d H%d", fy.Year(t), fy.HalfYear(t))
+}
+
+// months returns the calendar year in which the fiscal year t belongs to starts
+// and the number of whole fiscal months passed from its start.
+func (fy FiscalYear) months(t time.Time) (year, n int) {
+	year = t.Year()
+	if beforeDate(t, year, fy.Month, fy.Day) {
+		year--
+	}
+
+	n = (t.Year()-year)*12 + int(t.Month()-fy.Month)
+	if t.Day() < fy.Day {
+		n--
+	}
+
+	return year, n
+}
+
+// start returns the start of the fiscal unit of the given number of months
+// which is offset units away from the unit t belongs to.
+func (fy FiscalYear) start(t time.Time, months, offset int) time.Time {
+	year, n := fy.months(t)
+	n = n/months*months + offset*months
+
+	return startOfDay(year, fy.Month+time.Month(n), fy.Day, t.Location())
+}
+
+// end returns the end of the fiscal unit of the given number of months
+// which is offset units away from the unit t belongs to.
+func (fy FiscalYear) end(t time.Time, months, offset int) time.Time {
+	start := fy.start(t, months, offset)
+
+	return endOfDay(start.Year(), start.Month()+time.Month(months), fy.Day-1, t.Location())
+}
+
+// rules returns the quart, half year and year rules of the fiscal year.
+func (fy FiscalYear) rules() []TimeRule {
+	if fy == CalendarYear {
+		return []TimeRule{
+			&timeRuleStartOfThisQuart{},
+			&timeRuleEndOfThisQuart{},
+			&timeRuleStartOfPrevQuart{},
+			&timeRuleEndOfPrevQuart{},
+			&timeRuleStartOfNextQuart{},
+			&timeRuleEndOfNextQuart{},
+			&timeRuleStartOfThisHalfYear{},
+			&timeRuleEndOfThisHalfYear{},
+			&timeRuleStartOfPrevHalfYear{},
+			&timeRuleEndOfPrevHalfYear{},
+			&timeRuleStartOfNextHalfYear{},
+			&timeRuleEndOfNextHalfYear{},
+			&timeRuleStartOfThisYear{},
+			&timeRuleEndOfThisYear{},
+			&timeRuleStartOfPrevYear{},
+			&timeRuleEndOfPrevYear{},
+			&timeRuleStartOfNextYear{},
+			&timeRuleEndOfNextYear{},
+		}
+	}
+
+	var rules []TimeRule
+	for _, u := range []unit{unitQuart, unitHalfYear, unitYear} {
+		for _, offset := range []int{0, -1, 1} {
+			for _, a := range []anchor{anchorStart, anchorEnd} {
+				rules = append(rules, &timeRuleFiscal{
+					e:  timeExpr{anchor: a, offset: offset, unit: u},
+					fy: fy,
+				})
+			}
+		}
+	}
+
+	return rules
+}
+
+var fiscalMonths = map[unit]int{
+	unitQuart:    3,
+	unitHalfYear: 6,
+	unitYear:     12,
+}
+
+// timeRuleFiscal calculates the start or the end of a fiscal quart,
+// half year or year.
+type timeRuleFiscal struct {
+	e  timeExpr
+	fy FiscalYear
+}
+
+func (r *timeRuleFiscal) Calculate(pivot time.Time) time.Time {
+	if r.e.anchor == anchorEnd {
+		return r.fy.end(pivot, fiscalMonths[r.e.unit], r.e.offset)
+	}
+
+	return r.fy.start(pivot, fiscalMonths[r.e.unit], r.e.offset)
+}
+
+func (r *timeRuleFiscal) Shortcut() TimeShortcut { return r.e.shortcut() }
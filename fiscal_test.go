@@ -0,0 +1,240 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestTimeFactory_SetFiscalYear(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fy       rdate.FiscalYear
+		pivot    time.Time
+		sc       rdate.TimeShortcut
+		expected time.Time
+	}{
+		{
+			name:     "StartOfThisQuart(April 1)",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 1},
+			pivot:    time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisQuart,
+			expected: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfThisYear(April 1)",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 1},
+			pivot:    time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisYear,
+			expected: time.Date(2021, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfPrevYear(April 1)",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 1},
+			pivot:    time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevYear,
+			expected: time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfPrevHalfYear(July 1)",
+			fy:       rdate.FiscalYear{Month: time.July, Day: 1},
+			pivot:    time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfPrevHalfYear,
+			expected: time.Date(2020, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextQuart(July 1)",
+			fy:       rdate.FiscalYear{Month: time.July, Day: 1},
+			pivot:    time.Date(2021, 12, 31, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextQuart,
+			expected: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "StartOfThisYear(April 6)",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 6},
+			pivot:    time.Date(2021, 4, 5, 23, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisYear,
+			expected: time.Date(2020, 4, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfThisYear(April 6)",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 6},
+			pivot:    time.Date(2021, 4, 5, 23, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisYear,
+			expected: time.Date(2021, 4, 5, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextYear(April 6)",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 6},
+			pivot:    time.Date(2021, 4, 5, 23, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextYear,
+			expected: time.Date(2021, 4, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfThisQuart(April 6)",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 6},
+			pivot:    time.Date(2021, 1, 5, 23, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisQuart,
+			expected: time.Date(2021, 1, 5, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOf2QuartsAgo(April 6)",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 6},
+			pivot:    time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC),
+			sc:       "start 2 quarts ago",
+			expected: time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "StartOfThisYear(the calendar year)",
+			fy:       rdate.CalendarYear,
+			pivot:    time.Date(2021, 1, 6, 0, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeStartOfThisYear,
+			expected: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := rdate.NewTimeFactory()
+			f.SetFiscalYear(rdate.FiscalYear{Month: time.October, Day: 1})
+			f.SetFiscalYear(tc.fy)
+
+			actual, ok := f.Make(tc.pivot, tc.sc)
+			if !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			timeEqual(t, actual, tc.expected)
+		})
+	}
+}
+
+func TestTimeFactory_SetFiscalYearInvalid(t *testing.T) {
+	f := rdate.NewTimeFactory()
+	f.SetFiscalYear(rdate.FiscalYear{Month: time.April, Day: 31})
+	f.SetFiscalYear(rdate.FiscalYear{})
+
+	tm := f.Require(time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC), rdate.TimeStartOfThisYear)
+	timeEqual(t, tm, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestPeriodFactory_fiscalYear(t *testing.T) {
+	tf := rdate.NewTimeFactory()
+	tf.SetFiscalYear(rdate.FiscalYear{Month: time.April, Day: 6})
+
+	pf := rdate.NewPeriodFactory()
+	pf.SetTimeFactory(tf)
+
+	p := pf.Require(time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC), rdate.PeriodPrevQuart)
+	periodEqual(t, p,
+		time.Date(2020, 10, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 5, 23, 59, 59, 999999999, time.UTC))
+}
+
+func TestFiscalYear_labels(t *testing.T) {
+	testCases := []struct {
+		name     string
+		fy       rdate.FiscalYear
+		t        time.Time
+		year     string
+		halfYear string
+		quart    string
+	}{
+		{
+			name:     "CalendarYear",
+			fy:       rdate.CalendarYear,
+			t:        time.Date(2021, 8, 10, 0, 2, 1, 6, time.UTC),
+			year:     "FY2021",
+			halfYear: "FY2021 H2",
+			quart:    "FY2021 Q3",
+		},
+		{
+			name:     "April 1",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 1},
+			t:        time.Date(2020, 10, 10, 0, 2, 1, 6, time.UTC),
+			year:     "FY2021",
+			halfYear: "FY2021 H2",
+			quart:    "FY2021 Q3",
+		},
+		{
+			name:     "April 6",
+			fy:       rdate.FiscalYear{Month: time.April, Day: 6},
+			t:        time.Date(2021, 4, 5, 0, 2, 1, 6, time.UTC),
+			year:     "FY2021",
+			halfYear: "FY2021 H2",
+			quart:    "FY2021 Q4",
+		},
+		{
+			name:     "July 1",
+			fy:       rdate.FiscalYear{Month: time.July, Day: 1},
+			t:        time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
+			year:     "FY2022",
+			halfYear: "FY2022 H1",
+			quart:    "FY2022 Q1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := tc.fy.YearLabel(tc.t); actual != tc.year {
+				t.Errorf("expected: '%s', but actual: '%s'", tc.year, actual)
+			}
+			if actual := tc.fy.HalfYearLabel(tc.t); actual != tc.halfYear {
+				t.Errorf("expected: '%s', but actual: '%s'", tc.halfYear, actual)
+			}
+			if actual := tc.fy.QuartLabel(tc.t); actual != tc.quart {
+				t.Errorf("expected: '%s', but actual: '%s'", tc.quart, actual)
+			}
+		})
+	}
+}
+
+func TestTimeFactory_fiscalYearContinuity(t *testing.T) {
+	units := []struct {
+		start, end         rdate.TimeShortcut
+		prevStart, prevEnd rdate.TimeShortcut
+		nextStart          rdate.TimeShortcut
+	}{
+		{
+			rdate.TimeStartOfThisQuart, rdate.TimeEndOfThisQuart,
+			rdate.TimeStartOfPrevQuart, rdate.TimeEndOfPrevQuart,
+			rdate.TimeStartOfNextQuart,
+		},
+		{
+			rdate.TimeStartOfThisHalfYear, rdate.TimeEndOfThisHalfYear,
+			rdate.TimeStartOfPrevHalfYear, rdate.TimeEndOfPrevHalfYear,
+			rdate.TimeStartOfNextHalfYear,
+		},
+		{
+			rdate.TimeStartOfThisYear, rdate.TimeEndOfThisYear,
+			rdate.TimeStartOfPrevYear, rdate.TimeEndOfPrevYear,
+			rdate.TimeStartOfNextYear,
+		},
+	}
+
+	f := rdate.NewTimeFactory()
+	f.SetFiscalYear(rdate.FiscalYear{Month: time.April, Day: 6})
+
+	pivot := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 800; i++ {
+		ts := pivot.AddDate(0, 0, i)
+
+		for _, u := range units {
+			start := f.Require(ts, u.start).Time()
+			end := f.Require(ts, u.end).Time()
+
+			if ts.Before(start) || ts.After(end) {
+				t.Fatalf("%s is out of [%s, %s]", ts, start, end)
+			}
+
+			timeEqual(t, f.Require(ts, u.prevEnd), start.Add(-time.Nanosecond))
+			timeEqual(t, f.Require(ts, u.nextStart), end.Add(time.Nanosecond))
+			timeEqual(t, f.Require(ts, u.prevStart), f.Require(start.Add(-time.Nanosecond), u.start).Time())
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import "time"
+
+// FrozenTimeFactory is an immutable time factory.
+// It's safe for concurrent use by multiple goroutines by construction,
+// so Make and Require methods never block.
+//
+// It implements TimeMaker, TimeResolver and RuleLister but not TimeFactory,
+// there are no methods which change it. Use With methods instead,
+// they return modified copies.
+type FrozenTimeFactory struct {
+	f *unsafeTimeFactory
+}
+
+// NewFrozenTimeFactory creates an immutable time factory with the default rules
+// and the options applied in the given order, e.g.
+//
+//	f := rdate.NewFrozenTimeFactory(
+//		rdate.WithStartOfWeek(rdate.StartOfWeekSunday),
+//		rdate.WithLocation(loc),
+//	)
+func NewFrozenTimeFactory(opts ...TimeOption) *FrozenTimeFactory {
+	return &FrozenTimeFactory{
+		f: newUnsafeTimeFactoryWith(opts),
+	}
+}
+
+// With returns a copy of the factory with the options applied in the given order.
+func (f *FrozenTimeFactory) With(opts ...TimeOption) *FrozenTimeFactory {
+	c := f.f.clone()
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return &FrozenTimeFactory{f: c}
+}
+
+// WithStartOfWeek returns a copy of the factory with the start of the week.
+func (f *FrozenTimeFactory) WithStartOfWeek(s StartOfWeek) *FrozenTimeFactory {
+	return f.With(WithStartOfWeek(s))
+}
+
+// WithLocation returns a copy of the factory with the location
+// (see ConfigurableTimeFactory SetLocation).
+func (f *FrozenTimeFactory) WithLocation(loc *time.Location) *FrozenTimeFactory {
+	return f.With(WithLocation(loc))
+}
+
+// WithStringer returns a copy of the factory with the stringer.
+func (f *FrozenTimeFactory) WithStringer(s TimeStringer) *FrozenTimeFactory {
+	return f.With(WithTimeStringer(s))
+}
+
+// WithRules returns a copy of the factory extended by the rules.
+func (f *FrozenTimeFactory) WithRules(rules ...TimeRule) *FrozenTimeFactory {
+	return f.With(WithTimeRules(rules...))
+}
+
+// Make implements the TimeMaker Make method.
+func (f *FrozenTimeFactory) Make(pivot time.Time, sc TimeShortcut) (t Time, ok bool) {
+	return f.f.Make(pivot, sc)
+}
+
+// Require implements the TimeMaker Require method.
+func (f *FrozenTimeFactory) Require(pivot time.Time, sc TimeShortcut) Time {
+	return f.f.Require(pivot, sc)
+}
+
+// Resolve implements the TimeResolver Resolve method.
+func (f *FrozenTimeFactory) Resolve(pivot time.Time, sc TimeShortcut) (Time, error) {
+	return f.f.Resolve(pivot, sc)
+}
+
+// MustResolve implements the TimeResolver MustResolve method.
+func (f *FrozenTimeFactory) MustResolve(pivot time.Time, sc TimeShortcut) Time {
+	return f.f.MustResolve(pivot, sc)
+}
+
+// Rules implements the RuleLister Rules method.
+func (f *FrozenTimeFactory) Rules() []RuleInfo {
+	return f.f.Rules()
+}
+
+// Clone returns a mutable copy of the factory which is safe
+// for concurrent use (see NewTimeFactory).
+func (f *FrozenTimeFactory) Clone() ConfigurableTimeFactory {
+	return newSafeTimeFactory(f.f.clone())
+}
+
+// frozenTimes passes a frozen time factory to the period rules
+// which get a TimeFactory. The rules aren't supposed to change it,
+// so its Extend and setters do nothing.
+type frozenTimes struct {
+	*FrozenTimeFactory
+}
+
+func (f frozenTimes) location() *time.Location { return f.f.loc }
+
+func (f frozenTimes) Extend(rules []TimeRule) {}
+
+func (f frozenTimes) SetStartOfWeek(s StartOfWeek) {}
+
+func (f frozenTimes) SetStringer(s TimeStringer) {}
+
+// FrozenPeriodFactory is an immutable period factory.
+// It's safe for concurrent use by multiple goroutines by construction
+// as long as its time factory is.
+//
+// It implements PeriodMaker, PeriodResolver and RuleLister but not PeriodFactory,
+// there are no methods which change it. Use With methods instead,
+// they return modified copies.
+type FrozenPeriodFactory struct {
+	f *unsafePeriodFactory
+}
+
+// NewFrozenPeriodFactory creates an immutable period factory with the default rules
+// and the options applied in the given order.
+// The default time factory of it is NewFrozenTimeFactory().
+func NewFrozenPeriodFactory(opts ...PeriodOption) *FrozenPeriodFactory {
+	f := newUnsafePeriodFactory(defaultPeriodRules,
+		frozenTimes{NewFrozenTimeFactory()}, &defaultPeriodStringer{})
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return &FrozenPeriodFactory{f: f}
+}
+
+// With returns a copy of the factory with the options applied in the given order.
+func (f *FrozenPeriodFactory) With(opts ...PeriodOption) *FrozenPeriodFactory {
+	c := f.f.clone()
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return &FrozenPeriodFactory{f: c}
+}
+
+// WithTimeFactory returns a copy of the factory with the frozen time factory
+// (see WithFrozenTimeFactory option).
+func (f *FrozenPeriodFactory) WithTimeFactory(tf *FrozenTimeFactory) *FrozenPeriodFactory {
+	return f.With(WithFrozenTimeFactory(tf))
+}
+
+// WithLocation returns a copy of the factory with the location
+// (see ConfigurablePeriodFactory SetLocation).
+func (f *FrozenPeriodFactory) WithLocation(loc *time.Location) *FrozenPeriodFactory {
+	return f.With(WithPeriodLocation(loc))
+}
+
+// WithStringer returns a copy of the factory with the stringer.
+func (f *FrozenPeriodFactory) WithStringer(s PeriodStringer) *FrozenPeriodFactory {
+	return f.With(WithPeriodStringer(s))
+}
+
+// WithRules returns a copy of the factory extended by the rules.
+func (f *FrozenPeriodFactory) WithRules(rules ...PeriodRule) *FrozenPeriodFactory {
+	return f.With(WithPeriodRules(rules...))
+}
+
+// Make implements the PeriodMaker Make method.
+func (f *FrozenPeriodFactory) Make(pivot time.Time, sc PeriodShortcut) (p Period, ok bool) {
+	return f.f.Make(pivot, sc)
+}
+
+// Require implements the PeriodMaker Require method.
+func (f *FrozenPeriodFactory) Require(pivot time.Time, sc PeriodShortcut) Period {
+	return f.f.Require(pivot, sc)
+}
+
+// Resolve implements the PeriodResolver Resolve method.
+func (f *FrozenPeriodFactory) Resolve(pivot time.Time, sc PeriodShortcut) (Period, error) {
+	return f.f.Resolve(pivot, sc)
+}
+
+// MustResolve implements the PeriodResolver MustResolve method.
+func (f *FrozenPeriodFactory) MustResolve(pivot time.Time, sc PeriodShortcut) Period {
+	return f.f.MustResolve(pivot, sc)
+}
+
+// Rules implements the RuleLister Rules method.
+func (f *FrozenPeriodFactory) Rules() []RuleInfo {
+	return f.f.Rules()
+}
+
+// Clone returns a mutable copy of the factory which is safe
+// for concurrent use (see NewPeriodFactory).
+func (f *FrozenPeriodFactory) Clone() ConfigurablePeriodFactory {
+	return newSafePeriodFactory(f.f.clone())
+}
@@ -0,0 +1,157 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestFrozenTimeFactory(t *testing.T) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewFrozenTimeFactory(rdate.WithTimeRules(&testTimeRule{}))
+
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisWeek),
+		time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC))
+	if _, ok := f.Make(pivot, "my test time"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+
+	s := f.WithStartOfWeek(rdate.StartOfWeekSunday).
+		WithRules(&describedTimeRule{}).
+		WithStringer(&testTimeStringer{})
+
+	tm := s.Require(pivot, rdate.TimeStartOfThisWeek)
+	timeEqual(t, tm, time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC))
+	if tm.String() != "test stringer" {
+		t.Errorf("expected: 'test stringer', but actual: '%s'", tm.String())
+	}
+	if _, ok := s.Make(pivot, "lunch"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+
+	// the original factory isn't changed
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisWeek),
+		time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC))
+	if _, ok := f.Make(pivot, "lunch"); ok {
+		t.Errorf("expected ok = false but it's true")
+	}
+
+	u := f.With(rdate.WithoutTimeRules("my test time"), rdate.WithLocation(time.UTC))
+	if _, err := u.Resolve(pivot, "my test time"); err == nil {
+		t.Errorf("expected an error but it isn't")
+	}
+	if len(u.Rules()) != len(f.Rules())-1 {
+		t.Errorf("expected: %d rules, but actual: %d", len(f.Rules())-1, len(u.Rules()))
+	}
+
+	c := f.Clone()
+	c.Extend([]rdate.TimeRule{&describedTimeRule{}})
+	if _, ok := c.Make(pivot, "lunch"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+}
+
+// changingPeriodRule tries to change the time factory it gets.
+type changingPeriodRule struct{}
+
+func (r *changingPeriodRule) Calculate(pivot time.Time, tf rdate.TimeFactory) (from, to rdate.Time) {
+	tf.SetStartOfWeek(rdate.StartOfWeekSunday)
+	return tf.Require(pivot, rdate.TimeStartOfThisWeek), tf.Require(pivot, rdate.TimeEndOfThisWeek)
+}
+
+func (r *changingPeriodRule) Shortcut() rdate.PeriodShortcut { return "changing" }
+
+func TestFrozenFactories_readOnly(t *testing.T) {
+	var tf interface{} = rdate.NewFrozenTimeFactory()
+	if _, ok := tf.(rdate.TimeFactory); ok {
+		t.Errorf("expected a frozen time factory isn't a TimeFactory")
+	}
+	if _, ok := tf.(rdate.TimeMaker); !ok {
+		t.Errorf("expected a frozen time factory is a TimeMaker")
+	}
+
+	var pf interface{} = rdate.NewFrozenPeriodFactory()
+	if _, ok := pf.(rdate.PeriodFactory); ok {
+		t.Errorf("expected a frozen period factory isn't a PeriodFactory")
+	}
+	if _, ok := pf.(rdate.PeriodMaker); !ok {
+		t.Errorf("expected a frozen period factory is a PeriodMaker")
+	}
+
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+	f := rdate.NewFrozenPeriodFactory(rdate.WithPeriodRules(&changingPeriodRule{}))
+
+	// the changes are ignored, the week still starts on Monday
+	periodEqual(t, f.Require(pivot, "changing"),
+		time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 12, 23, 59, 59, 999999999, time.UTC))
+	periodEqual(t, f.Require(pivot, rdate.PeriodThisWeek),
+		time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 12, 23, 59, 59, 999999999, time.UTC))
+}
+
+func TestFrozenPeriodFactory(t *testing.T) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewFrozenPeriodFactory()
+
+	periodEqual(t, f.Require(pivot, rdate.PeriodThisWeek),
+		time.Date(2020, 7, 6, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 12, 23, 59, 59, 999999999, time.UTC))
+
+	s := f.WithTimeFactory(rdate.NewFrozenTimeFactory().WithStartOfWeek(rdate.StartOfWeekSunday)).
+		WithRules(&testPeriodRule{}).
+		WithStringer(&testPeriodStringer{})
+
+	p := s.MustResolve(pivot, rdate.PeriodThisWeek)
+	periodEqual(t, p,
+		time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 11, 23, 59, 59, 999999999, time.UTC))
+	if p.String() != "test period stringer" {
+		t.Errorf("expected: 'test period stringer', but actual: '%s'", p.String())
+	}
+	if _, ok := s.Make(pivot, "my test period"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+
+	// the original factory isn't changed
+	if _, ok := f.Make(pivot, "my test period"); ok {
+		t.Errorf("expected ok = false but it's true")
+	}
+	if u := f.With(rdate.WithoutPeriodRules(rdate.PeriodPrevWeek)); len(u.Rules()) != len(f.Rules())-1 {
+		t.Errorf("expected: %d rules, but actual: %d", len(f.Rules())-1, len(u.Rules()))
+	}
+
+	c := f.Clone()
+	c.Extend([]rdate.PeriodRule{&testPeriodRule{}})
+	if _, ok := c.Make(pivot, "my test period"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+}
+
+func TestFrozenPeriodFactory_concurrentUse(t *testing.T) {
+	f := rdate.NewFrozenPeriodFactory()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			pivot := time.Date(2020, 7, 8+i, 0, 2, 1, 6, time.UTC)
+			for j := 0; j < 100; j++ {
+				f.Require(pivot, rdate.PeriodPrevMonth)
+				f.WithRules(&testPeriodRule{}).Require(pivot, "my test period")
+			}
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,42 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import "time"
+
+// IntervalMode defines whether the end of a period belongs to it.
+type IntervalMode int8
+
+const (
+	// IntervalClosed makes periods closed [from, to], the end of a period
+	// is its last moment, e.g. 23:59:59.999999999. It's the default mode.
+	IntervalClosed IntervalMode = iota
+
+	// IntervalHalfOpen makes periods half-open [from, to), the end of a period
+	// is the first moment after it, e.g. the start of the next day.
+	// It suits queries like "created_at >= from AND created_at < to"
+	// which neither lose the last fraction of a second nor catch the next day.
+	IntervalHalfOpen
+)
+
+func (m IntervalMode) valid() bool {
+	return m == IntervalClosed || m == IntervalHalfOpen
+}
+
+// exclusiveEnd returns the first moment after the last moment of a period,
+// the precision of the end is respected (see ConfigurableTimeFactory SetPrecision).
+// A zero-value is returned as is, so a failed rule stays failed.
+func exclusiveEnd(to Time) Time {
+	if to.t.IsZero() {
+		return to
+	}
+
+	step := time.Nanosecond
+	if to.precision > 0 {
+		step = to.precision
+	}
+
+	return Time{t: to.t.Add(step), s: to.s, precision: to.precision}
+}
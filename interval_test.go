@@ -0,0 +1,133 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestPeriodFactory_SetIntervalMode(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	testCases := []struct {
+		name   string
+		sc     rdate.PeriodShortcut
+		from   time.Time
+		closed time.Time
+		end    time.Time
+	}{
+		{
+			name:   "prev day",
+			sc:     rdate.PeriodPrevDay,
+			from:   time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+			closed: time.Date(2020, 8, 10, 23, 59, 59, 999999999, time.UTC),
+			end:    time.Date(2020, 8, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "this month",
+			sc:     rdate.PeriodThisMonth,
+			from:   time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			closed: time.Date(2020, 8, 31, 23, 59, 59, 999999999, time.UTC),
+			end:    time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "parsed",
+			sc:     "prev 2 quarts",
+			from:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			closed: time.Date(2020, 6, 30, 23, 59, 59, 999999999, time.UTC),
+			end:    time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "trailing",
+			sc:     rdate.PeriodLast24Hours,
+			from:   time.Date(2020, 8, 10, 0, 2, 1, 6, time.UTC),
+			closed: time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			end:    time.Date(2020, 8, 11, 0, 2, 1, 7, time.UTC),
+		},
+	}
+
+	closed := rdate.NewPeriodFactory()
+	halfOpen := rdate.NewPeriodFactory()
+	halfOpen.SetIntervalMode(rdate.IntervalHalfOpen)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := closed.Require(pivot, tc.sc)
+			periodEqual(t, p, tc.from, tc.closed)
+			if !p.End().Time().Equal(tc.end) || p.Mode() != rdate.IntervalClosed {
+				t.Errorf("closed: end %s (%d), expected %s", p.End(), p.Mode(), tc.end)
+			}
+
+			p = halfOpen.Require(pivot, tc.sc)
+			periodEqual(t, p, tc.from, tc.end)
+			if !p.End().Time().Equal(tc.end) || p.Mode() != rdate.IntervalHalfOpen {
+				t.Errorf("half-open: end %s (%d), expected %s", p.End(), p.Mode(), tc.end)
+			}
+		})
+	}
+}
+
+func TestPeriodFactory_SetIntervalModeInvalid(t *testing.T) {
+	f := rdate.NewPeriodFactory(rdate.WithIntervalMode(rdate.IntervalHalfOpen))
+	f.SetIntervalMode(rdate.IntervalMode(42))
+
+	p := f.Require(time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC), rdate.PeriodThisDay)
+	if p.Mode() != rdate.IntervalHalfOpen {
+		t.Errorf("expected the half-open mode, got %d", p.Mode())
+	}
+}
+
+func TestPeriodFactory_IntervalModeStringers(t *testing.T) {
+	pivot := time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC)
+	fy := rdate.FiscalYear{Month: time.April, Day: 1}
+
+	f := rdate.NewFrozenPeriodFactory(rdate.WithIntervalMode(rdate.IntervalHalfOpen))
+
+	if s := f.Require(pivot, rdate.PeriodPrevDay).String(); s !=
+		"2021-02-09 00:00:00 — 2021-02-10 00:00:00" {
+		t.Errorf("unexpected string: %s", s)
+	}
+
+	tf := rdate.NewFrozenTimeFactory(rdate.WithFiscalYear(fy))
+	f = f.WithTimeFactory(tf).WithStringer(rdate.NewFiscalPeriodStringer(fy))
+
+	for sc, expected := range map[rdate.PeriodShortcut]string{
+		rdate.PeriodThisQuart: "FY2021 Q4",
+		"prev 2 quarts":       "FY2021 Q2 — FY2021 Q3",
+	} {
+		if s := f.Require(pivot, sc).String(); s != expected {
+			t.Errorf("%s: expected %s, got %s", sc, expected, s)
+		}
+	}
+}
+
+func TestCopyOnWritePeriodFactory_SetIntervalMode(t *testing.T) {
+	f := rdate.NewCopyOnWritePeriodFactory()
+	f.SetIntervalMode(rdate.IntervalHalfOpen)
+
+	periodEqual(t, f.Require(time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC), rdate.PeriodThisDay),
+		time.Date(2020, 8, 11, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 12, 0, 0, 0, 0, time.UTC))
+}
+
+func TestPeriod_EndPrecision(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+	tf := rdate.NewTimeFactory(rdate.WithPrecision(time.Second))
+
+	p := rdate.NewPeriodFactory(rdate.WithTimeFactory(tf)).Require(pivot, rdate.PeriodPrevDay)
+	periodEqual(t, p,
+		time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 10, 23, 59, 59, 0, time.UTC))
+	timeEqual(t, p.End(), time.Date(2020, 8, 11, 0, 0, 0, 0, time.UTC))
+
+	p = rdate.NewPeriodFactory(rdate.WithTimeFactory(tf),
+		rdate.WithIntervalMode(rdate.IntervalHalfOpen)).Require(pivot, rdate.PeriodMonthToDate)
+	periodEqual(t, p,
+		time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 11, 0, 2, 1, 7, time.UTC))
+}
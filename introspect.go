@@ -0,0 +1,270 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RuleInfo describes a rule registered in a factory, e.g. for a UI picker.
+//
+// Unit is the unit the rule is built on, Direction is the direction
+// of the unit from the pivot and Kind is the kind of the rule
+// (see the RuleUnit, RuleDirection and RuleKind constants).
+// Any of them is empty if it's unknown or doesn't make sense.
+type RuleInfo struct {
+	Shortcut    string
+	Description string
+	Unit        RuleUnit
+	Direction   RuleDirection
+	Kind        RuleKind
+}
+
+// RuleUnit is the unit a rule is built on.
+type RuleUnit string
+
+const (
+	RuleUnitMinute      RuleUnit = "minute"
+	RuleUnitHour        RuleUnit = "hour"
+	RuleUnitDay         RuleUnit = "day"
+	RuleUnitBusinessDay RuleUnit = "business day"
+	RuleUnitWeek        RuleUnit = "week"
+	RuleUnitMonth       RuleUnit = "month"
+	RuleUnitQuart       RuleUnit = "quart"
+	RuleUnitHalfYear    RuleUnit = "half year"
+	RuleUnitYear        RuleUnit = "year"
+	RuleUnitISOYear     RuleUnit = "iso year"
+)
+
+// RuleDirection is the direction of the unit of a rule from the pivot.
+type RuleDirection string
+
+const (
+	RuleDirectionThis RuleDirection = "this"
+	RuleDirectionPrev RuleDirection = "prev"
+	RuleDirectionNext RuleDirection = "next"
+)
+
+// RuleKind is the kind of a rule. RuleKindStart, RuleKindEnd and RuleKindAsIs
+// are the kinds of time rules, the others are the kinds of period rules.
+type RuleKind string
+
+const (
+	RuleKindStart    RuleKind = "start"
+	RuleKindEnd      RuleKind = "end"
+	RuleKindAsIs     RuleKind = "as is"
+	RuleKindPeriod   RuleKind = "period"
+	RuleKindTrailing RuleKind = "trailing"
+	RuleKindToDate   RuleKind = "to date"
+)
+
+// RuleLister is an optional interface of a time or period factory
+// which lists its rules. The factories of the package implement it.
+type RuleLister interface {
+	// Rules returns the info of every rule registered in the factory
+	// sorted by the shortcuts. The shortcuts which are parsed by the grammar
+	// aren't listed (see TimeFactory Make and PeriodFactory Make).
+	Rules() []RuleInfo
+}
+
+// listShortcuts returns the shortcuts of the rules of the factory
+// if it implements RuleLister.
+func listShortcuts(f interface{}) []string {
+	l, ok := f.(RuleLister)
+	if !ok {
+		return nil
+	}
+
+	infos := l.Rules()
+	known := make([]string, len(infos))
+	for i, info := range infos {
+		known[i] = info.Shortcut
+	}
+
+	return known
+}
+
+// RuleMetadata is an optional interface which TimeRule and PeriodRule
+// implementations can provide to describe themselves.
+// If a rule doesn't implement it, its info is derived from the shortcut
+// when the shortcut matches the grammar; otherwise only the shortcut is known.
+type RuleMetadata interface {
+	Metadata() RuleInfo
+}
+
+func direction(first, last int) RuleDirection {
+	switch {
+	case last < 0:
+		return RuleDirectionPrev
+	case first > 0:
+		return RuleDirectionNext
+	default:
+		return RuleDirectionThis
+	}
+}
+
+// describeOffset returns a human description of the unit which is offset units
+// away from the unit the pivot belongs to, e.g. "the previous month".
+func describeOffset(offset int, u unit) string {
+	switch offset {
+	case -1:
+		return "the previous " + unitNames[u]
+	case 0:
+		return "this " + unitNames[u]
+	case 1:
+		return "the next " + unitNames[u]
+	}
+
+	return "the " + unitNames[u] + " " + formatOffset(offset, u)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var anchorDescriptions = map[anchor]string{
+	anchorStart: "start of ",
+	anchorEnd:   "end of ",
+	anchorAsIs:  "the same moment of ",
+}
+
+// timeRuleInfo returns the info of the time rule.
+func timeRuleInfo(r TimeRule) RuleInfo {
+	sc := string(r.Shortcut())
+
+	if m, ok := r.(RuleMetadata); ok {
+		info := m.Metadata()
+		info.Shortcut = sc
+
+		return info
+	}
+
+	e, ok := parseTimeExpr(r.Shortcut())
+	if !ok {
+		return RuleInfo{Shortcut: sc, Description: sc}
+	}
+
+	return RuleInfo{
+		Shortcut:    sc,
+		Description: capitalize(anchorDescriptions[e.anchor] + describeOffset(e.offset, e.unit)),
+		Unit:        RuleUnit(unitNames[e.unit]),
+		Direction:   direction(e.offset, e.offset),
+		Kind:        RuleKind(anchorNames[e.anchor]),
+	}
+}
+
+// periodRuleInfo returns the info of the period rule.
+func periodRuleInfo(r PeriodRule) RuleInfo {
+	sc := string(r.Shortcut())
+
+	if m, ok := r.(RuleMetadata); ok {
+		info := m.Metadata()
+		info.Shortcut = sc
+
+		return info
+	}
+
+	e, ok := parsePeriodExpr(r.Shortcut())
+	if !ok {
+		return RuleInfo{Shortcut: sc, Description: sc}
+	}
+
+	var desc string
+	switch n := e.last - e.first + 1; {
+	case n == 1:
+		desc = describeOffset(e.first, e.unit)
+	case e.last == 0:
+		desc = formatNumberOfUnits(n, e.unit) + " up to this one"
+	case e.last == -1:
+		desc = "the previous " + formatNumberOfUnits(n, e.unit)
+	case e.first == 1:
+		desc = "the next " + formatNumberOfUnits(n, e.unit)
+	case e.last < 0:
+		desc = formatNumberOfUnits(n, e.unit) + " up to " + describeOffset(e.last, e.unit)
+	default:
+		desc = formatNumberOfUnits(n, e.unit) + " from " + describeOffset(e.first, e.unit)
+	}
+
+	return RuleInfo{
+		Shortcut:    sc,
+		Description: capitalize(desc),
+		Unit:        RuleUnit(unitNames[e.unit]),
+		Direction:   direction(e.first, e.last),
+		Kind:        RuleKindPeriod,
+	}
+}
+
+// Metadata implements the RuleMetadata interface.
+func (r *timeRuleAsIs) Metadata() RuleInfo {
+	return RuleInfo{Description: "The pivot as is", Kind: RuleKindAsIs}
+}
+
+// Metadata implements the RuleMetadata interface.
+func (p *periodRuleTrailing) Metadata() RuleInfo {
+	desc := "The last " + strconv.Itoa(p.n) + " " + trailingUnitNames[p.u]
+	if p.n != 1 {
+		desc += "s"
+	}
+
+	dir := RuleDirectionPrev
+	if p.includeToday {
+		desc += " including today"
+		dir = RuleDirectionThis
+	}
+
+	return RuleInfo{
+		Description: desc,
+		Unit:        RuleUnit(trailingUnitNames[p.u]),
+		Direction:   dir,
+		Kind:        RuleKindTrailing,
+	}
+}
+
+// Metadata implements the RuleMetadata interface.
+func (p *periodRuleToDate) Metadata() RuleInfo {
+	desc := unitNames[p.unit] + " to date"
+	if p.offset != 0 {
+		desc += " of " + describeOffset(p.offset, p.unit)
+	}
+
+	return RuleInfo{
+		Description: capitalize(desc),
+		Unit:        RuleUnit(unitNames[p.unit]),
+		Direction:   direction(p.offset, p.offset),
+		Kind:        RuleKindToDate,
+	}
+}
+
+// Metadata implements the RuleMetadata interface.
+func (p *periodRulePrevBusinessDay) Metadata() RuleInfo {
+	return RuleInfo{
+		Description: "The previous business day",
+		Unit:        RuleUnitBusinessDay,
+		Direction:   RuleDirectionPrev,
+		Kind:        RuleKindPeriod,
+	}
+}
+
+// Metadata implements the RuleMetadata interface.
+func (p *periodRuleNextBusinessDay) Metadata() RuleInfo {
+	return RuleInfo{
+		Description: "The next business day",
+		Unit:        RuleUnitBusinessDay,
+		Direction:   RuleDirectionNext,
+		Kind:        RuleKindPeriod,
+	}
+}
+
+func sortRuleInfos(infos []RuleInfo) {
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Shortcut < infos[j].Shortcut
+	})
+}
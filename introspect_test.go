@@ -0,0 +1,189 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+type describedTimeRule struct{}
+
+func (r *describedTimeRule) Calculate(pivot time.Time) time.Time { return pivot }
+
+func (r *describedTimeRule) Shortcut() rdate.TimeShortcut { return "lunch" }
+
+func (r *describedTimeRule) Metadata() rdate.RuleInfo {
+	return rdate.RuleInfo{Description: "Lunch time", Unit: rdate.RuleUnitDay, Kind: rdate.RuleKindAsIs}
+}
+
+type shortcutPeriodRule struct {
+	sc rdate.PeriodShortcut
+}
+
+func (r *shortcutPeriodRule) Calculate(pivot time.Time, tf rdate.TimeFactory) (from, to rdate.Time) {
+	return tf.Require(pivot, rdate.TimeAsIs), tf.Require(pivot, rdate.TimeAsIs)
+}
+
+func (r *shortcutPeriodRule) Shortcut() rdate.PeriodShortcut { return r.sc }
+
+func findRuleInfo(t *testing.T, infos []rdate.RuleInfo, sc string) rdate.RuleInfo {
+	t.Helper()
+
+	for _, info := range infos {
+		if info.Shortcut == sc {
+			return info
+		}
+	}
+
+	t.Fatalf("'%s' isn't listed", sc)
+	return rdate.RuleInfo{}
+}
+
+func TestTimeFactory_Rules(t *testing.T) {
+	f := rdate.NewTimeFactory()
+	f.Extend([]rdate.TimeRule{&describedTimeRule{}, &testTimeRule{}})
+
+	infos := f.Rules()
+	if len(infos) != 45 {
+		t.Errorf("expected: 45 rules, but actual: %d", len(infos))
+	}
+
+	for i := 1; i < len(infos); i++ {
+		if infos[i-1].Shortcut >= infos[i].Shortcut {
+			t.Errorf("'%s' and '%s' aren't sorted", infos[i-1].Shortcut, infos[i].Shortcut)
+		}
+	}
+
+	testCases := []rdate.RuleInfo{
+		{
+			Shortcut:    "start prev month",
+			Description: "Start of the previous month",
+			Unit:        rdate.RuleUnitMonth,
+			Direction:   rdate.RuleDirectionPrev,
+			Kind:        rdate.RuleKindStart,
+		},
+		{
+			Shortcut:    "end this half year",
+			Description: "End of this half year",
+			Unit:        rdate.RuleUnitHalfYear,
+			Direction:   rdate.RuleDirectionThis,
+			Kind:        rdate.RuleKindEnd,
+		},
+		{
+			Shortcut:    "start next iso year",
+			Description: "Start of the next iso year",
+			Unit:        rdate.RuleUnitISOYear,
+			Direction:   rdate.RuleDirectionNext,
+			Kind:        rdate.RuleKindStart,
+		},
+		{
+			Shortcut:    "as is",
+			Description: "The pivot as is",
+			Kind:        rdate.RuleKindAsIs,
+		},
+		{
+			Shortcut:    "lunch",
+			Description: "Lunch time",
+			Unit:        rdate.RuleUnitDay,
+			Kind:        rdate.RuleKindAsIs,
+		},
+		{
+			Shortcut:    "my test time",
+			Description: "my test time",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Shortcut, func(t *testing.T) {
+			if actual := findRuleInfo(t, infos, tc.Shortcut); actual != tc {
+				t.Errorf("expected: %+v, but actual: %+v", tc, actual)
+			}
+		})
+	}
+}
+
+func TestPeriodFactory_Rules(t *testing.T) {
+	f := rdate.NewPeriodFactory()
+	f.Extend([]rdate.PeriodRule{
+		&shortcutPeriodRule{sc: "prev 3 months"},
+		&shortcutPeriodRule{sc: "this 2 weeks"},
+		&shortcutPeriodRule{sc: "2 days ago"},
+	})
+
+	infos := f.Rules()
+	if len(infos) != 39 {
+		t.Errorf("expected: 39 rules, but actual: %d", len(infos))
+	}
+
+	testCases := []rdate.RuleInfo{
+		{
+			Shortcut:    "prev quart",
+			Description: "The previous quart",
+			Unit:        rdate.RuleUnitQuart,
+			Direction:   rdate.RuleDirectionPrev,
+			Kind:        rdate.RuleKindPeriod,
+		},
+		{
+			Shortcut:    "this year",
+			Description: "This year",
+			Unit:        rdate.RuleUnitYear,
+			Direction:   rdate.RuleDirectionThis,
+			Kind:        rdate.RuleKindPeriod,
+		},
+		{
+			Shortcut:    "prev 3 months",
+			Description: "The previous 3 months",
+			Unit:        rdate.RuleUnitMonth,
+			Direction:   rdate.RuleDirectionPrev,
+			Kind:        rdate.RuleKindPeriod,
+		},
+		{
+			Shortcut:    "this 2 weeks",
+			Description: "2 weeks up to this one",
+			Unit:        rdate.RuleUnitWeek,
+			Direction:   rdate.RuleDirectionThis,
+			Kind:        rdate.RuleKindPeriod,
+		},
+		{
+			Shortcut:    "2 days ago",
+			Description: "The day 2 days ago",
+			Unit:        rdate.RuleUnitDay,
+			Direction:   rdate.RuleDirectionPrev,
+			Kind:        rdate.RuleKindPeriod,
+		},
+		{
+			Shortcut:    "last 7 days",
+			Description: "The last 7 days",
+			Unit:        rdate.RuleUnitDay,
+			Direction:   rdate.RuleDirectionPrev,
+			Kind:        rdate.RuleKindTrailing,
+		},
+		{
+			Shortcut:    "prev month to date",
+			Description: "Month to date of the previous month",
+			Unit:        rdate.RuleUnitMonth,
+			Direction:   rdate.RuleDirectionPrev,
+			Kind:        rdate.RuleKindToDate,
+		},
+		{
+			Shortcut:    "next business day",
+			Description: "The next business day",
+			Unit:        rdate.RuleUnitBusinessDay,
+			Direction:   rdate.RuleDirectionNext,
+			Kind:        rdate.RuleKindPeriod,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Shortcut, func(t *testing.T) {
+			if actual := findRuleInfo(t, infos, tc.Shortcut); actual != tc {
+				t.Errorf("expected: %+v, but actual: %+v", tc, actual)
+			}
+		})
+	}
+}
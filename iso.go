@@ -0,0 +1,109 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isoYearStart returns the start of the ISO 8601 week-year,
+// i.e. Monday of the week which contains January 4.
+func isoYearStart(year int, loc *time.Location) time.Time {
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+	return startOfDay(year, 1, 4-daysSinceMonday(jan4), loc)
+}
+
+// isoWeekStart returns the start of the ISO 8601 week of the week-year.
+// ok is false if the week-year has no such week, e.g. the week 53
+// of a week-year of 52 weeks.
+func isoWeekStart(year, week int, loc *time.Location) (ts time.Time, ok bool) {
+	start := isoYearStart(year, loc)
+	ts = startOfDay(start.Year(), start.Month(), start.Day()+(week-1)*7, loc)
+
+	if y, _ := ts.ISOWeek(); y != year {
+		return time.Time{}, false
+	}
+
+	return ts, true
+}
+
+// timeRuleISOYear calculates the start or the end of the ISO 8601 week-year
+// which is offset years away from the week-year the pivot belongs to.
+type timeRuleISOYear struct {
+	e timeExpr
+}
+
+func (r *timeRuleISOYear) Calculate(pivot time.Time) time.Time {
+	year, _ := pivot.ISOWeek()
+	year += r.e.offset
+
+	if r.e.anchor == anchorEnd {
+		ts := isoYearStart(year+1, pivot.Location())
+		return endOfDay(ts.Year(), ts.Month(), ts.Day()-1, pivot.Location())
+	}
+
+	return isoYearStart(year, pivot.Location())
+}
+
+func (r *timeRuleISOYear) Shortcut() TimeShortcut { return r.e.shortcut() }
+
+// timeRuleISOWeek calculates the start or the end of the ISO 8601 week
+// with the given number of the week-year which is offset years away
+// from the week-year the pivot belongs to.
+// It has no result (a zero time.Time) if the week-year has no such week.
+type timeRuleISOWeek struct {
+	sc     TimeShortcut
+	anchor anchor
+	week   int
+	offset int
+}
+
+func (r *timeRuleISOWeek) Calculate(pivot time.Time) time.Time {
+	year, _ := pivot.ISOWeek()
+
+	ts, ok := isoWeekStart(year+r.offset, r.week, pivot.Location())
+	if !ok {
+		return time.Time{}
+	}
+
+	if r.anchor == anchorEnd {
+		return endOfDay(ts.Year(), ts.Month(), ts.Day()+6, pivot.Location())
+	}
+
+	return ts
+}
+
+func (r *timeRuleISOWeek) Shortcut() TimeShortcut { return r.sc }
+
+// parseISOWeek parses a shortcut of an ISO 8601 week:
+//
+//	shortcut = ("start" | "end") "iso week" number offset
+//	offset   = see parseTimeExpr, the unit must be "year" or "iso year"
+//
+// The year is always the ISO 8601 week-year, e.g. "start iso week 33 this year".
+// The week 53 exists only in the long week-years, the factory has no result
+// for it in other years.
+func parseISOWeek(sc TimeShortcut) (TimeRule, bool) {
+	words := strings.Fields(string(sc))
+
+	a, words, ok := parseAnchor(words)
+	if !ok || a == anchorAsIs || len(words) < 3 || words[0] != "iso" || words[1] != "week" {
+		return nil, false
+	}
+
+	week, err := strconv.Atoi(words[2])
+	if err != nil || week < 1 || week > 53 || words[2][0] == '+' {
+		return nil, false
+	}
+
+	offset, u, ok := parseOffset(words[3:])
+	if !ok || (u != unitYear && u != unitISOYear) {
+		return nil, false
+	}
+
+	return &timeRuleISOWeek{sc: sc, anchor: a, week: week, offset: offset}, true
+}
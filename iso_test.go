@@ -0,0 +1,211 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestTimeFactory_isoWeekDate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pivot    time.Time
+		sc       rdate.TimeShortcut
+		expected time.Time
+	}{
+		{
+			name:     "TimeStartOfThisISOYear",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisISOYear,
+			expected: time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisISOYear",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisISOYear,
+			expected: time.Date(2022, 1, 2, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisISOYear(January 3)",
+			pivot:    time.Date(2021, 1, 3, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisISOYear,
+			expected: time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisISOYear(December 30)",
+			pivot:    time.Date(2019, 12, 30, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisISOYear,
+			expected: time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfPrevISOYear",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevISOYear,
+			expected: time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfPrevISOYear",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfPrevISOYear,
+			expected: time.Date(2021, 1, 3, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextISOYear",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextISOYear,
+			expected: time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextISOYear",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextISOYear,
+			expected: time.Date(2023, 1, 1, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "start 2 iso years ago",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       "start 2 iso years ago",
+			expected: time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "start iso week 33 this year",
+			pivot:    time.Date(2021, 1, 1, 0, 2, 1, 6, time.UTC),
+			sc:       "start iso week 33 this year",
+			expected: time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "end iso week 53 this year",
+			pivot:    time.Date(2020, 5, 1, 0, 2, 1, 6, time.UTC),
+			sc:       "end iso week 53 this year",
+			expected: time.Date(2021, 1, 3, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "end iso week 1 next year",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       "end iso week 1 next year",
+			expected: time.Date(2022, 1, 9, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "start iso week 10 2 iso years ago",
+			pivot:    time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       "start iso week 10 2 iso years ago",
+			expected: time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	f := rdate.NewTimeFactory()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := f.Make(tc.pivot, tc.sc)
+			if !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			timeEqual(t, actual, tc.expected)
+		})
+	}
+}
+
+func TestTimeFactory_isoWeekDateInvalid(t *testing.T) {
+	testCases := []rdate.TimeShortcut{
+		"start iso week 0 this year",
+		"start iso week 54 this year",
+		"start iso week +3 this year",
+		"as is iso week 3 this year",
+		"start iso week 3 this month",
+		"start iso week this year",
+		"start iso week 3",
+	}
+
+	f := rdate.NewTimeFactory()
+
+	for _, sc := range testCases {
+		t.Run(string(sc), func(t *testing.T) {
+			if _, ok := f.Make(time.Date(2021, 8, 11, 0, 2, 1, 6, time.UTC), sc); ok {
+				t.Errorf("expected not ok but it is")
+			}
+		})
+	}
+}
+
+func TestTimeFactory_isoWeek53(t *testing.T) {
+	f := rdate.NewTimeFactory()
+
+	// 2020 has 53 ISO weeks
+	tm, ok := f.Make(time.Date(2020, 5, 1, 0, 2, 1, 6, time.UTC), "start iso week 53 this year")
+	if !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+	timeEqual(t, tm, time.Date(2020, 12, 28, 0, 0, 0, 0, time.UTC))
+
+	// 2021 has 52 ISO weeks, the week doesn't roll into 2022
+	pivot := time.Date(2021, 5, 1, 0, 2, 1, 6, time.UTC)
+	for _, sc := range []rdate.TimeShortcut{
+		"start iso week 53 this year",
+		"end iso week 53 this year",
+		"start iso week 53 next year",
+	} {
+		if tm, ok := f.Make(pivot, sc); ok || !tm.IsZero() {
+			t.Errorf("%s: expected not ok and a zero-value, but actual: %v %s", sc, ok, tm)
+		}
+		if _, err := f.Resolve(pivot, sc); !errors.Is(err, rdate.ErrNoResult) {
+			t.Errorf("%s: expected ErrNoResult but actual: %v", sc, err)
+		}
+	}
+
+	if _, ok := f.Make(pivot, "start iso week 53 prev year"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+}
+
+func TestPeriodFactory_isoYear(t *testing.T) {
+	f := rdate.NewPeriodFactory()
+
+	p := f.Require(time.Date(2021, 1, 2, 0, 2, 1, 6, time.UTC), rdate.PeriodThisISOYear)
+	periodEqual(t, p,
+		time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 3, 23, 59, 59, 999999999, time.UTC))
+
+	p = f.Require(time.Date(2021, 1, 2, 0, 2, 1, 6, time.UTC), "prev 2 iso years")
+	periodEqual(t, p,
+		time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 12, 29, 23, 59, 59, 999999999, time.UTC))
+}
+
+func TestISOWeek(t *testing.T) {
+	testCases := []struct {
+		name string
+		t    time.Time
+		year int
+		week int
+	}{
+		{"January 1, 2021", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), 2020, 53},
+		{"January 4, 2021", time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC), 2021, 1},
+		{"December 30, 2019", time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC), 2020, 1},
+		{"December 31, 2023", time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), 2023, 52},
+	}
+
+	tf := rdate.NewTimeFactory()
+	pf := rdate.NewPeriodFactory()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			year, week := tf.Require(tc.t, rdate.TimeAsIs).ISOWeek()
+			if year != tc.year || week != tc.week {
+				t.Errorf("expected: %d-W%02d, but actual: %d-W%02d", tc.year, tc.week, year, week)
+			}
+
+			year, week = pf.Require(tc.t, rdate.PeriodThisDay).ISOWeek()
+			if year != tc.year || week != tc.week {
+				t.Errorf("expected: %d-W%02d, but actual: %d-W%02d", tc.year, tc.week, year, week)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import "time"
+
+// TimeOption configures a time factory during its construction
+// (see NewTimeFactory, NewNonblockingTimeFactory and NewFrozenTimeFactory).
+type TimeOption func(f *unsafeTimeFactory)
+
+// WithStartOfWeek sets the start of the week (see TimeFactory SetStartOfWeek).
+func WithStartOfWeek(s StartOfWeek) TimeOption {
+	return func(f *unsafeTimeFactory) { f.SetStartOfWeek(s) }
+}
+
+// WithFiscalYear sets the fiscal year (see ConfigurableTimeFactory SetFiscalYear).
+func WithFiscalYear(fy FiscalYear) TimeOption {
+	return func(f *unsafeTimeFactory) { f.SetFiscalYear(fy) }
+}
+
+// WithRetailCalendar moves the week, month, quart, half year and year rules
+// onto the retail calendar (see NewRetailTimeFactory).
+// If the calendar is invalid, the option is ignored.
+func WithRetailCalendar(c RetailCalendar) TimeOption {
+	return func(f *unsafeTimeFactory) {
+		if c.validate() == nil {
+			f.Extend(c.rules())
+		}
+	}
+}
+
+// WithWeekend sets the weekend days (see ConfigurableTimeFactory SetWeekend).
+func WithWeekend(days ...time.Weekday) TimeOption {
+	return func(f *unsafeTimeFactory) { f.SetWeekend(days...) }
+}
+
+// WithHolidayCalendar sets the calendar of holidays
+// (see ConfigurableTimeFactory SetHolidayCalendar).
+func WithHolidayCalendar(c HolidayCalendar) TimeOption {
+	return func(f *unsafeTimeFactory) { f.SetHolidayCalendar(c) }
+}
+
+// WithDayStart sets the clock at which a day starts (see ConfigurableTimeFactory SetDayStart).
+func WithDayStart(offset time.Duration) TimeOption {
+	return func(f *unsafeTimeFactory) { f.SetDayStart(offset) }
+}
+
+// WithPrecision sets the precision of the ends of units
+// (see ConfigurableTimeFactory SetPrecision).
+func WithPrecision(p time.Duration) TimeOption {
+	return func(f *unsafeTimeFactory) { f.SetPrecision(p) }
+}
+
+// WithLocation sets the location pivots are converted into
+// (see ConfigurableTimeFactory SetLocation).
+func WithLocation(loc *time.Location) TimeOption {
+	return func(f *unsafeTimeFactory) { f.SetLocation(loc) }
+}
+
+// WithTimeStringer sets the stringer of Time objects (see TimeFactory SetStringer).
+func WithTimeStringer(s TimeStringer) TimeOption {
+	return func(f *unsafeTimeFactory) { f.SetStringer(s) }
+}
+
+// WithTimeRules appends new rules or replaces existing ones
+// (see TimeFactory Extend).
+func WithTimeRules(rules ...TimeRule) TimeOption {
+	return func(f *unsafeTimeFactory) { f.Extend(rules) }
+}
+
+// WithoutTimeRules removes the rules registered with the given shortcuts
+// (see ConfigurableTimeFactory Remove).
+func WithoutTimeRules(shortcuts ...TimeShortcut) TimeOption {
+	return func(f *unsafeTimeFactory) { f.Remove(shortcuts...) }
+}
+
+// PeriodOption configures a period factory during its construction
+// (see NewPeriodFactory, NewNonblockingPeriodFactory and NewFrozenPeriodFactory).
+type PeriodOption func(f *unsafePeriodFactory)
+
+// WithTimeFactory sets the time factory which is used by the period rules
+// (see PeriodFactory SetTimeFactory).
+func WithTimeFactory(tf TimeFactory) PeriodOption {
+	return func(f *unsafePeriodFactory) { f.SetTimeFactory(tf) }
+}
+
+// WithFrozenTimeFactory sets the frozen time factory which is used
+// by the period rules (see PeriodFactory SetTimeFactory).
+// The rules get it as a TimeFactory whose Extend and setters do nothing,
+// so the rules can't change it.
+func WithFrozenTimeFactory(tf *FrozenTimeFactory) PeriodOption {
+	return func(f *unsafePeriodFactory) { f.SetTimeFactory(frozenTimes{tf}) }
+}
+
+// WithPeriodLocation sets the location pivots are converted into
+// (see ConfigurablePeriodFactory SetLocation).
+func WithPeriodLocation(loc *time.Location) PeriodOption {
+	return func(f *unsafePeriodFactory) { f.SetLocation(loc) }
+}
+
+// WithIntervalMode sets whether the ends of periods belong to them
+// (see ConfigurablePeriodFactory SetIntervalMode).
+func WithIntervalMode(m IntervalMode) PeriodOption {
+	return func(f *unsafePeriodFactory) { f.SetIntervalMode(m) }
+}
+
+// WithPeriodStringer sets the stringer of Period objects
+// (see PeriodFactory SetStringer).
+func WithPeriodStringer(s PeriodStringer) PeriodOption {
+	return func(f *unsafePeriodFactory) { f.SetStringer(s) }
+}
+
+// WithPeriodRules appends new rules or replaces existing ones
+// (see PeriodFactory Extend).
+func WithPeriodRules(rules ...PeriodRule) PeriodOption {
+	return func(f *unsafePeriodFactory) { f.Extend(rules) }
+}
+
+// WithoutPeriodRules removes the rules registered with the given shortcuts
+// (see ConfigurablePeriodFactory Remove).
+func WithoutPeriodRules(shortcuts ...PeriodShortcut) PeriodOption {
+	return func(f *unsafePeriodFactory) { f.Remove(shortcuts...) }
+}
@@ -0,0 +1,96 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestNewTimeFactory_options(t *testing.T) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewTimeFactory(
+		rdate.WithStartOfWeek(rdate.StartOfWeekSunday),
+		rdate.WithTimeRules(&testTimeRule{}),
+		rdate.WithoutTimeRules(rdate.TimeEndOfPrevYear),
+		rdate.WithTimeStringer(&testTimeStringer{}),
+		rdate.WithDayStart(time.Hour),
+	)
+
+	tm := f.Require(pivot, rdate.TimeStartOfThisWeek)
+	timeEqual(t, tm, time.Date(2020, 7, 5, 1, 0, 0, 0, time.UTC))
+	if tm.String() != "test stringer" {
+		t.Errorf("expected: 'test stringer', but actual: '%s'", tm.String())
+	}
+
+	if _, ok := f.Make(pivot, "my test time"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+	if _, ok := f.Make(pivot, rdate.TimeEndOfPrevYear); ok {
+		t.Errorf("expected ok = false (removed) but it's true")
+	}
+
+	nf := rdate.NewNonblockingTimeFactory(
+		rdate.WithFiscalYear(rdate.FiscalYear{Month: time.April, Day: 1}),
+		rdate.WithWeekend(time.Friday, time.Saturday),
+		rdate.WithHolidayCalendar(rdate.NewHolidayCalendar([]time.Time{
+			time.Date(2020, 7, 2, 0, 0, 0, 0, time.UTC),
+		})),
+	)
+
+	timeEqual(t, nf.Require(pivot, rdate.TimeStartOfThisYear),
+		time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC))
+	timeEqual(t, nf.Require(time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC),
+		rdate.TimeStartOfPrevBusinessDay),
+		time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestNewTimeFactory_WithLocation(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("tzdata is unavailable: %v", err)
+	}
+
+	f := rdate.NewTimeFactory(rdate.WithLocation(moscow))
+
+	tm := f.Require(time.Date(2020, 8, 31, 22, 0, 0, 0, time.UTC), rdate.TimeStartOfThisMonth)
+	timeEqual(t, tm, time.Date(2020, 9, 1, 0, 0, 0, 0, moscow))
+	if tm.Time().Location() != moscow {
+		t.Errorf("expected: %s, but actual: %s", moscow, tm.Time().Location())
+	}
+}
+
+func TestNewPeriodFactory_options(t *testing.T) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewNonblockingPeriodFactory(
+		rdate.WithTimeFactory(rdate.NewTimeFactory(
+			rdate.WithStartOfWeek(rdate.StartOfWeekSunday))),
+		rdate.WithPeriodRules(&testPeriodRule{}),
+		rdate.WithoutPeriodRules(rdate.PeriodLast30Days),
+		rdate.WithPeriodStringer(&testPeriodStringer{}),
+	)
+
+	p := f.Require(pivot, rdate.PeriodThisWeek)
+	periodEqual(t, p,
+		time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 11, 23, 59, 59, 999999999, time.UTC))
+	if p.String() != "test period stringer" {
+		t.Errorf("expected: 'test period stringer', but actual: '%s'", p.String())
+	}
+
+	if _, ok := f.Make(pivot, "my test period"); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+
+	for _, info := range f.Rules() {
+		if info.Shortcut == string(rdate.PeriodLast30Days) {
+			t.Errorf("expected '%s' is removed but it isn't", rdate.PeriodLast30Days)
+		}
+	}
+}
@@ -24,15 +24,57 @@ const (
 	PeriodPrevQuart    PeriodShortcut = "prev quart"
 	PeriodPrevHalfYear PeriodShortcut = "prev half year"
 	PeriodPrevYear     PeriodShortcut = "prev year"
+	PeriodNextDay      PeriodShortcut = "next day"
+	PeriodNextWeek     PeriodShortcut = "next week"
+	PeriodNextMonth    PeriodShortcut = "next month"
+	PeriodNextQuart    PeriodShortcut = "next quart"
+	PeriodNextHalfYear PeriodShortcut = "next half year"
+	PeriodNextYear     PeriodShortcut = "next year"
+	PeriodThisISOYear  PeriodShortcut = "this iso year"
+	PeriodPrevISOYear  PeriodShortcut = "prev iso year"
+	PeriodNextISOYear  PeriodShortcut = "next iso year"
+
+	PeriodPrevBusinessDay PeriodShortcut = "prev business day"
+	PeriodNextBusinessDay PeriodShortcut = "next business day"
+	PeriodLast24Hours     PeriodShortcut = "last 24 hours"
+	PeriodLast7Days       PeriodShortcut = "last 7 days"
+	PeriodLast30Days      PeriodShortcut = "last 30 days"
+
+	PeriodWeekToDate         PeriodShortcut = "week to date"
+	PeriodMonthToDate        PeriodShortcut = "month to date"
+	PeriodQuartToDate        PeriodShortcut = "quart to date"
+	PeriodHalfYearToDate     PeriodShortcut = "half year to date"
+	PeriodYearToDate         PeriodShortcut = "year to date"
+	PeriodPrevWeekToDate     PeriodShortcut = "prev week to date"
+	PeriodPrevMonthToDate    PeriodShortcut = "prev month to date"
+	PeriodPrevQuartToDate    PeriodShortcut = "prev quart to date"
+	PeriodPrevHalfYearToDate PeriodShortcut = "prev half year to date"
+	PeriodPrevYearToDate     PeriodShortcut = "prev year to date"
 )
 
 // PeriodFactory is used to make new Period objects by passing the pivot and the shortcut.
 // Method NewPeriod and RequirePeriod uses the default period factory which is created
 // by calling NewPeriodFactory during the init.
+//
+// The factories of the package implement ConfigurablePeriodFactory
+// which extends the interface by more settings and the optional interfaces
+// (see PeriodResolver and RuleLister).
 type PeriodFactory interface {
 	// Make creates a new Period object by using the rule which is found (or not)
 	// by the given PeriodShortcut.
-	// If the rule is not found, ok will be false and t will be a zero-value of Period.
+	// If there is no rule registered with the shortcut, the shortcut is parsed
+	// as a period of one unit (e.g. "3 months ago" or "in 2 quarts")
+	// or a period of N whole units (e.g. "prev 3 months", "this 2 quarts"
+	// or "next 10 days") or a range of whole units (e.g. "6 to 4 months ago"
+	// or "in 2 to 3 quarts"). The time factory calculates the bounds of such
+	// periods, so they are consistent with the single-unit rules.
+	// Shortcuts like "last 15 minutes", "last 12 hours", "last 14 days"
+	// or "last 2 weeks including today" are parsed as trailing windows
+	// (see NewTrailingPeriodRule), and shortcuts like "month to date"
+	// or "2 years ago to date" are parsed as to-date periods.
+	// Registered rules always take priority.
+	// If the rule is not found or it can't calculate the bounds (e.g. a bound
+	// is a zero-value of Time), ok will be false and t will be a zero-value of Period.
 	Make(pivot time.Time, sc PeriodShortcut) (p Period, ok bool)
 
 	// Require creates new Period object by using the rule which is found (or not)
@@ -54,14 +96,85 @@ type PeriodFactory interface {
 	SetStringer(s PeriodStringer)
 }
 
+// PeriodMaker is the read-only part of PeriodFactory. It's implemented
+// by every period factory of the package including FrozenPeriodFactory.
+type PeriodMaker interface {
+	// Make creates a new Period object (see PeriodFactory Make).
+	Make(pivot time.Time, sc PeriodShortcut) (p Period, ok bool)
+
+	// Require creates a new Period object (see PeriodFactory Require).
+	Require(pivot time.Time, sc PeriodShortcut) Period
+}
+
+// PeriodResolver is an optional interface of a period factory
+// which reports why a shortcut can't be resolved.
+// ResolvePeriod and MustResolvePeriod use it if the default period factory
+// implements it.
+type PeriodResolver interface {
+	// Resolve creates a new Period object like Make does, but if the rule
+	// is not found, it returns an *UnknownShortcutError error which carries
+	// the shortcut and the nearest registered ones. If the rule can't calculate
+	// the bounds (e.g. it relies on a time shortcut which is removed from
+	// the time factory), the error of the time factory is returned
+	// or an error which wraps ErrNoResult.
+	Resolve(pivot time.Time, sc PeriodShortcut) (Period, error)
+
+	// MustResolve is like Resolve but panics if the rule is not found.
+	// It simplifies the initialization of variables holding periods.
+	MustResolve(pivot time.Time, sc PeriodShortcut) Period
+}
+
+// ConfigurablePeriodFactory is a period factory with the settings
+// and the capabilities of the period factories of the package.
+// It's returned by NewPeriodFactory and the other constructors.
+type ConfigurablePeriodFactory interface {
+	PeriodFactory
+	PeriodResolver
+	RuleLister
+
+	// Remove removes the rules registered with the given shortcuts
+	// from the period factory. Unknown shortcuts are ignored.
+	// A removed shortcut isn't parsed by the grammar either, e.g. removing
+	// "last 7 days" makes it unknown, until a rule is registered
+	// with it again (see Extend).
+	Remove(shortcuts ...PeriodShortcut)
+
+	// Clone returns an independent copy of the period factory with the same rules
+	// and stringer, so the copy can be extended or shrunk without affecting
+	// the original one. The copy is of the same kind (safe or nonblocking),
+	// except a frozen factory which is copied to a safe one.
+	// The time factory is shared by the copy, use SetTimeFactory to replace it.
+	Clone() ConfigurablePeriodFactory
+
+	// SetLocation sets the location of the time factory of the period factory
+	// (see ConfigurableTimeFactory SetLocation), so the periods and the times
+	// of the time factory never disagree. Every pivot is converted into it
+	// before any rule runs. A frozen time factory (see WithFrozenTimeFactory)
+	// is replaced by its copy with the location. If the time factory
+	// has no SetLocation method, the call is ignored.
+	// The default value is nil which means pivots are used as is.
+	SetLocation(loc *time.Location)
+
+	// SetIntervalMode sets whether the ends of new periods belong to them.
+	// In IntervalHalfOpen mode the To method of every period returns
+	// the first moment after the period (e.g. the start of the next unit)
+	// and stringers get it as the end. The rules are the same in both modes.
+	// The default value is IntervalClosed, invalid values are ignored.
+	SetIntervalMode(m IntervalMode)
+}
+
 type unsafePeriodFactory struct {
 	rules map[PeriodShortcut]PeriodRule
-	tf    TimeFactory
-	s     PeriodStringer
+	// removed is the set of the removed shortcuts which aren't parsed
+	// by the grammar (see Remove).
+	removed map[PeriodShortcut]struct{}
+	tf      TimeFactory
+	s       PeriodStringer
+	mode    IntervalMode
 }
 
 func newUnsafePeriodFactory(rules []PeriodRule, tf TimeFactory,
-	s PeriodStringer) PeriodFactory {
+	s PeriodStringer) *unsafePeriodFactory {
 	f := &unsafePeriodFactory{
 		rules: map[PeriodShortcut]PeriodRule{},
 		tf:    tf,
@@ -75,19 +188,43 @@ func newUnsafePeriodFactory(rules []PeriodRule, tf TimeFactory,
 
 // Make implements the PeriodFactory Make method.
 func (f *unsafePeriodFactory) Make(pivot time.Time, sc PeriodShortcut) (p Period, ok bool) {
-	r, ok := f.rules[sc]
+	r, ok := f.rule(sc)
 	if !ok {
 		return Period{}, false
 	}
 
-	from, to := r.Calculate(pivot, f.tf)
+	p, err := f.calculate(r, pivot, sc)
+	if err != nil {
+		return Period{}, false
+	}
+
+	return p, true
+}
+
+// calculate runs the rule with the settings of the factory
+// (the location, the interval mode and the stringer).
+// The error is returned if the rule can't calculate the bounds
+// (see calculateBounds).
+func (f *unsafePeriodFactory) calculate(r PeriodRule, pivot time.Time,
+	sc PeriodShortcut) (Period, error) {
+	if loc := timeLocation(f.tf); loc != nil {
+		pivot = pivot.In(loc)
+	}
+
+	from, to, err := calculateBounds(r, pivot, f.tf, sc)
+	if err != nil {
+		return Period{}, err
+	}
+
+	p := Period{
+		mode:  f.mode,
+		sc:    sc,
+		s:     f.s,
+		tf:    f.tf,
+		pivot: pivot,
+	}
 
-	return Period{
-		from: from,
-		to:   to,
-		sc:   sc,
-		s:    f.s,
-	}, true
+	return p.bound(from, to), nil
 }
 
 // Require implements the PeriodFactory Require method.
@@ -100,11 +237,133 @@ func (f *unsafePeriodFactory) Require(pivot time.Time, sc PeriodShortcut) Period
 	return p
 }
 
+// Resolve implements the PeriodResolver Resolve method.
+func (f *unsafePeriodFactory) Resolve(pivot time.Time, sc PeriodShortcut) (Period, error) {
+	r, ok := f.rule(sc)
+	if !ok {
+		known := make([]string, 0, len(f.rules))
+		for k := range f.rules {
+			known = append(known, string(k))
+		}
+
+		return Period{}, newUnknownShortcutError(string(sc), known)
+	}
+
+	return f.calculate(r, pivot, sc)
+}
+
+// MustResolve implements the PeriodResolver MustResolve method.
+func (f *unsafePeriodFactory) MustResolve(pivot time.Time, sc PeriodShortcut) Period {
+	p, err := f.Resolve(pivot, sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// rule returns the rule which is registered with the shortcut
+// or parsed from it.
+func (f *unsafePeriodFactory) rule(sc PeriodShortcut) (PeriodRule, bool) {
+	if r, ok := f.rules[sc]; ok {
+		return r, true
+	}
+
+	if _, ok := f.removed[sc]; ok {
+		return nil, false
+	}
+
+	return f.parse(sc)
+}
+
+// parse builds a rule for the shortcut which isn't registered in the factory
+// but matches the shortcut grammar (see parsePeriodExpr).
+func (f *unsafePeriodFactory) parse(sc PeriodShortcut) (PeriodRule, bool) {
+	if r, ok := parseTrailing(sc); ok {
+		return r, true
+	}
+
+	if r, ok := parseToDate(sc); ok {
+		return r, true
+	}
+
+	e, ok := parsePeriodExpr(sc)
+	if !ok {
+		return nil, false
+	}
+
+	return &periodRuleExpr{sc: sc, e: e}, true
+}
+
+// Remove implements the ConfigurablePeriodFactory Remove method.
+func (f *unsafePeriodFactory) Remove(shortcuts ...PeriodShortcut) {
+	if f.removed == nil && len(shortcuts) > 0 {
+		f.removed = map[PeriodShortcut]struct{}{}
+	}
+
+	for _, sc := range shortcuts {
+		delete(f.rules, sc)
+		f.removed[sc] = struct{}{}
+	}
+}
+
+// Clone implements the ConfigurablePeriodFactory Clone method.
+func (f *unsafePeriodFactory) Clone() ConfigurablePeriodFactory {
+	return f.clone()
+}
+
+func (f *unsafePeriodFactory) clone() *unsafePeriodFactory {
+	c := *f
+	c.rules = make(map[PeriodShortcut]PeriodRule, len(f.rules))
+	for sc, r := range f.rules {
+		c.rules[sc] = r
+	}
+
+	c.removed = nil
+	if len(f.removed) > 0 {
+		c.removed = make(map[PeriodShortcut]struct{}, len(f.removed))
+		for sc := range f.removed {
+			c.removed[sc] = struct{}{}
+		}
+	}
+
+	return &c
+}
+
+// Rules implements the RuleLister Rules method.
+func (f *unsafePeriodFactory) Rules() []RuleInfo {
+	infos := make([]RuleInfo, 0, len(f.rules))
+	for _, r := range f.rules {
+		infos = append(infos, periodRuleInfo(r))
+	}
+
+	sortRuleInfos(infos)
+
+	return infos
+}
+
 // SetTimeFactory implements the PeriodFactory SetTimeFactory method.
 func (f *unsafePeriodFactory) SetTimeFactory(tf TimeFactory) {
 	f.tf = tf
 }
 
+// SetLocation implements the ConfigurablePeriodFactory SetLocation method.
+func (f *unsafePeriodFactory) SetLocation(loc *time.Location) {
+	switch tf := f.tf.(type) {
+	case frozenTimes:
+		f.tf = frozenTimes{tf.WithLocation(loc)}
+	case interface{ SetLocation(loc *time.Location) }:
+		tf.SetLocation(loc)
+	}
+}
+
+// SetIntervalMode implements the ConfigurablePeriodFactory SetIntervalMode method.
+func (f *unsafePeriodFactory) SetIntervalMode(m IntervalMode) {
+	if m.valid() {
+		f.mode = m
+	}
+}
+
 // SetStringer implements the PeriodFactory SetStringer method.
 func (f *unsafePeriodFactory) SetStringer(s PeriodStringer) {
 	f.s = s
@@ -114,6 +373,7 @@ func (f *unsafePeriodFactory) SetStringer(s PeriodStringer) {
 func (f *unsafePeriodFactory) Extend(rules []PeriodRule) {
 	for _, r := range rules {
 		f.rules[r.Shortcut()] = r
+		delete(f.removed, r.Shortcut())
 	}
 }
 
@@ -130,14 +390,38 @@ var defaultPeriodRules = []PeriodRule{
 	&periodRulePrevQuart{},
 	&periodRulePrevHalfYear{},
 	&periodRulePrevYear{},
+	&periodRuleNextDay{},
+	&periodRuleNextWeek{},
+	&periodRuleNextMonth{},
+	&periodRuleNextQuart{},
+	&periodRuleNextHalfYear{},
+	&periodRuleNextYear{},
+	&periodRuleThisISOYear{},
+	&periodRulePrevISOYear{},
+	&periodRuleNextISOYear{},
+	&periodRulePrevBusinessDay{},
+	&periodRuleNextBusinessDay{},
+	&periodRuleTrailing{sc: PeriodLast24Hours, n: 24, u: TrailingHour},
+	&periodRuleTrailing{sc: PeriodLast7Days, n: 7, u: TrailingDay},
+	&periodRuleTrailing{sc: PeriodLast30Days, n: 30, u: TrailingDay},
+	&periodRuleToDate{sc: PeriodWeekToDate, offset: 0, unit: unitWeek},
+	&periodRuleToDate{sc: PeriodMonthToDate, offset: 0, unit: unitMonth},
+	&periodRuleToDate{sc: PeriodQuartToDate, offset: 0, unit: unitQuart},
+	&periodRuleToDate{sc: PeriodHalfYearToDate, offset: 0, unit: unitHalfYear},
+	&periodRuleToDate{sc: PeriodYearToDate, offset: 0, unit: unitYear},
+	&periodRuleToDate{sc: PeriodPrevWeekToDate, offset: -1, unit: unitWeek},
+	&periodRuleToDate{sc: PeriodPrevMonthToDate, offset: -1, unit: unitMonth},
+	&periodRuleToDate{sc: PeriodPrevQuartToDate, offset: -1, unit: unitQuart},
+	&periodRuleToDate{sc: PeriodPrevHalfYearToDate, offset: -1, unit: unitHalfYear},
+	&periodRuleToDate{sc: PeriodPrevYearToDate, offset: -1, unit: unitYear},
 }
 
-var defaultPeriodFactory = NewPeriodFactory()
+var defaultPeriodFactory PeriodFactory = defaultCalendar.Periods()
 
 // safePeriodFactory is a decorator which wraps a period factory for concurrent use
 // by multiple goroutines.
 type safePeriodFactory struct {
-	f  PeriodFactory
+	f  *unsafePeriodFactory
 	rw sync.RWMutex
 }
 
@@ -157,6 +441,48 @@ func (f *safePeriodFactory) Require(pivot time.Time, sc PeriodShortcut) Period {
 	return f.f.Require(pivot, sc)
 }
 
+// Resolve implements the PeriodResolver Resolve method.
+func (f *safePeriodFactory) Resolve(pivot time.Time, sc PeriodShortcut) (Period, error) {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+
+	return f.f.Resolve(pivot, sc)
+}
+
+// MustResolve implements the PeriodResolver MustResolve method.
+func (f *safePeriodFactory) MustResolve(pivot time.Time, sc PeriodShortcut) Period {
+	p, err := f.Resolve(pivot, sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// Remove implements the ConfigurablePeriodFactory Remove method.
+func (f *safePeriodFactory) Remove(shortcuts ...PeriodShortcut) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.Remove(shortcuts...)
+}
+
+// Clone implements the ConfigurablePeriodFactory Clone method.
+func (f *safePeriodFactory) Clone() ConfigurablePeriodFactory {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+
+	return newSafePeriodFactory(f.f.clone())
+}
+
+// Rules implements the RuleLister Rules method.
+func (f *safePeriodFactory) Rules() []RuleInfo {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+
+	return f.f.Rules()
+}
+
 // SetTimeFactory implements the PeriodFactory SetTimeFactory method.
 func (f *safePeriodFactory) SetTimeFactory(tf TimeFactory) {
 	f.rw.Lock()
@@ -165,6 +491,22 @@ func (f *safePeriodFactory) SetTimeFactory(tf TimeFactory) {
 	f.f.SetTimeFactory(tf)
 }
 
+// SetLocation implements the ConfigurablePeriodFactory SetLocation method.
+func (f *safePeriodFactory) SetLocation(loc *time.Location) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.SetLocation(loc)
+}
+
+// SetIntervalMode implements the ConfigurablePeriodFactory SetIntervalMode method.
+func (f *safePeriodFactory) SetIntervalMode(m IntervalMode) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.SetIntervalMode(m)
+}
+
 // SetStringer implements the PeriodFactory SetStringer method.
 func (f *safePeriodFactory) SetStringer(s PeriodStringer) {
 	f.rw.Lock()
@@ -181,15 +523,16 @@ func (f *safePeriodFactory) Extend(rules []PeriodRule) {
 	f.f.Extend(rules)
 }
 
-func newSafePeriodFactory(f PeriodFactory) PeriodFactory {
+func newSafePeriodFactory(f *unsafePeriodFactory) *safePeriodFactory {
 	return &safePeriodFactory{f: f}
 }
 
 // NewPeriodFactory creates a period factory which is ready to extend and
 // safe for concurrent use by multiple goroutines.
-func NewPeriodFactory() PeriodFactory {
+// The options are applied to the factory in the given order.
+func NewPeriodFactory(opts ...PeriodOption) ConfigurablePeriodFactory {
 	return newSafePeriodFactory(
-		NewNonblockingPeriodFactory())
+		newUnsafePeriodFactoryWith(opts))
 }
 
 // NewNonblockingPeriodFactory creates an unsafe period factory which is ready to extend.
@@ -200,9 +543,21 @@ func NewPeriodFactory() PeriodFactory {
 //
 // It might be useful when your application is under high load and your period factory
 // doesn't use Extend method at all or use it once during the init.
-func NewNonblockingPeriodFactory() PeriodFactory {
-	return newUnsafePeriodFactory(defaultPeriodRules,
+// The options are applied to the factory in the given order.
+func NewNonblockingPeriodFactory(opts ...PeriodOption) ConfigurablePeriodFactory {
+	return newUnsafePeriodFactoryWith(opts)
+}
+
+// newUnsafePeriodFactoryWith creates an unsafe period factory with the default rules,
+// a nonblocking time factory and the options applied in the given order.
+func newUnsafePeriodFactoryWith(opts []PeriodOption) *unsafePeriodFactory {
+	f := newUnsafePeriodFactory(defaultPeriodRules,
 		NewNonblockingTimeFactory(), &defaultPeriodStringer{})
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
 }
 
 // SetDefaultPeriodFactory sets your own period factory as default.
@@ -210,6 +565,12 @@ func NewNonblockingPeriodFactory() PeriodFactory {
 // After that you can use NewPeriod or RequirePeriod functions
 // without concreting a factory like that rdate.NewPeriod(...)
 // or rdate.RequirePeriod(...))
+//
+// Unless the factory uses the default time factory, the settings
+// of the default time factory (e.g. SetDefaultStartOfWeek) don't affect it,
+// use SetDefaultCalendar to replace both of them.
+// The interval mode is the setting of the period factory itself,
+// so it's never shared with the time factory.
 func SetDefaultPeriodFactory(f PeriodFactory) {
 	defaultPeriodFactory = f
 }
@@ -217,8 +578,14 @@ func SetDefaultPeriodFactory(f PeriodFactory) {
 type Period struct {
 	from Time
 	to   Time
+	end  Time
+	mode IntervalMode
 	sc   PeriodShortcut
 	s    PeriodStringer
+	// tf is the time factory the period is made by (see Split).
+	tf TimeFactory
+	// pivot is the pivot the period is calculated by (see Prev and Next).
+	pivot time.Time
 }
 
 // NewPeriod calls Make method of the default period factory.
@@ -231,16 +598,57 @@ func RequirePeriod(pivot time.Time, sc PeriodShortcut) Period {
 	return defaultPeriodFactory.Require(pivot, sc)
 }
 
+// ResolvePeriod calls Resolve method of the default period factory.
+// If the factory doesn't implement PeriodResolver, its Make method is called
+// and the error of an unknown shortcut carries the nearest of its rules
+// only if it implements RuleLister.
+func ResolvePeriod(pivot time.Time, sc PeriodShortcut) (Period, error) {
+	f := defaultPeriodFactory
+	if r, ok := f.(PeriodResolver); ok {
+		return r.Resolve(pivot, sc)
+	}
+
+	p, ok := f.Make(pivot, sc)
+	if !ok {
+		return Period{}, newUnknownShortcutError(string(sc), listShortcuts(f))
+	}
+
+	return p, nil
+}
+
+// MustResolvePeriod is like ResolvePeriod but panics if the rule is not found.
+func MustResolvePeriod(pivot time.Time, sc PeriodShortcut) Period {
+	p, err := ResolvePeriod(pivot, sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
 // From is a getter of the from Time value of the type.
 func (p Period) From() Time {
 	return p.from
 }
 
 // To is a getter of the to Time value of the type.
+// It's the last moment of the period or the first moment after it
+// depending on the interval mode of the factory (see Mode).
 func (p Period) To() Time {
 	return p.to
 }
 
+// End returns the first moment after the period, e.g. the start
+// of the next unit, whatever the interval mode is.
+func (p Period) End() Time {
+	return p.end
+}
+
+// Mode returns the interval mode of the factory which made the period.
+func (p Period) Mode() IntervalMode {
+	return p.mode
+}
+
 func (p Period) String() string {
 	if p.s == nil {
 		return ""
@@ -249,6 +657,25 @@ func (p Period) String() string {
 	return p.s.String(p.from, p.to, p.sc)
 }
 
+// ISOWeek returns the ISO 8601 week-year and the week number
+// in which the period starts.
+func (p Period) ISOWeek() (year, week int) {
+	return p.from.ISOWeek()
+}
+
+// bound sets the bounds of the period which are calculated by a rule,
+// the end is converted to the interval mode of the period.
+func (p Period) bound(from, to Time) Period {
+	p.from = from
+	p.to = to
+	p.end = exclusiveEnd(to)
+	if p.mode == IntervalHalfOpen {
+		p.to = p.end
+	}
+
+	return p
+}
+
 // IsZero reports if the value is a zero-value of the type
 func (p Period) IsZero() bool {
 	return p.s == nil && p.from.IsZero() && p.to.IsZero()
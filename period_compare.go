@@ -0,0 +1,138 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import "time"
+
+var unitsPerYear = map[unit]int{
+	unitMonth:    12,
+	unitQuart:    4,
+	unitHalfYear: 2,
+	unitYear:     1,
+	unitISOYear:  1,
+}
+
+// LastYear returns the period to compare the period with "year over year"
+// on the calendar: the same dates a year earlier.
+//
+// A period of months, quarts, half years or years (e.g. "this month"
+// or "prev 2 quarts") is moved by its units, so it stays whole
+// (e.g. February 2020 of 29 days is compared with February 2019 of 28 days)
+// and follows the fiscal year of the time factory. Its shortcut is rewritten
+// like Prev does it (e.g. "this 3 months" becomes "14 to 12 months ago"),
+// so the result is moved by its units again.
+//
+// Other periods are moved by the dates keeping the time elapsed
+// from the start of the day, their shortcuts are empty.
+// The leap year rules for them are:
+//   - February 29 of a start becomes February 28, so "this day"
+//     of 2020-02-29 is compared with 2019-02-28;
+//   - an end on February 29 becomes February 28 too, but a period
+//     which ends with the whole February 29 or February 28 ends
+//     with the whole February 28 (not at its start);
+//   - a period which includes February 29 is a day longer than the one
+//     it's compared with, e.g. 2020-02-15 — 2020-03-14 (29 days)
+//     is compared with 2019-02-15 — 2019-03-14 (28 days);
+//   - a period of a common year compared with a leap year never gets
+//     February 29 unless it includes the whole February.
+func (p Period) LastYear() Period {
+	if p.tf == nil || p.empty() {
+		return p
+	}
+
+	if e, ok := parsePeriodExpr(p.sc); ok {
+		if n, ok := unitsPerYear[e.unit]; ok {
+			return p.shiftExpr(e, -n)
+		}
+	}
+
+	return p.moveDates(-1, 0)
+}
+
+// LastYearByWeekday returns the period to compare the period with
+// "year over year" by the weekdays: the same days 52 weeks (364 days) earlier,
+// so Mondays are compared with Mondays. There are no leap year rules,
+// every date is moved back by 364 days, so the dates drift by a day
+// a year (two days after February 29).
+//
+// A period of days or weeks is moved by its units and its shortcut
+// is rewritten like Prev does it, the shortcuts of other periods are empty.
+func (p Period) LastYearByWeekday() Period {
+	if p.tf == nil || p.empty() {
+		return p
+	}
+
+	if e, ok := parsePeriodExpr(p.sc); ok {
+		switch e.unit {
+		case unitDay:
+			return p.shiftExpr(e, -364)
+		case unitWeek:
+			return p.shiftExpr(e, -52)
+		}
+	}
+
+	return p.moveDates(0, -364)
+}
+
+// PrevEqualLength returns the period of the same length which ends
+// right before the period starts, e.g. the 31 days before August.
+// A period of whole days is measured in calendar days, so daylight saving time
+// doesn't move it, other periods are measured by their duration.
+//
+// A period of days or weeks is the same as Prev(1) gives,
+// the shortcuts of other periods are empty.
+func (p Period) PrevEqualLength() Period {
+	if p.tf == nil || p.empty() {
+		return p
+	}
+
+	if e, ok := parsePeriodExpr(p.sc); ok && (e.unit == unitDay || e.unit == unitWeek) {
+		return p.shiftExpr(e, -(e.last - e.first + 1))
+	}
+
+	from := p.from.t
+	if isDayStart(from) && isDayStart(p.end.t) {
+		from = moveDate(from, 0, -civilDays(from, p.end.t), false)
+	} else {
+		from = from.Add(-p.end.t.Sub(from))
+	}
+
+	return p.span(Time{t: from, s: p.from.s}, Time{t: p.from.t, s: p.end.s})
+}
+
+// moveDates moves the bounds of the period by the years and the days
+// (see moveDate).
+func (p Period) moveDates(years, days int) Period {
+	return p.span(
+		Time{t: moveDate(p.from.t, years, days, false), s: p.from.s},
+		Time{t: moveDate(p.end.t, years, days, true), s: p.end.s},
+	)
+}
+
+// moveDate moves t by the years and the days on the calendar keeping the time
+// elapsed from the start of its day. February 29 becomes February 28
+// in a common year, unless t is an exclusive end at the start
+// of February 29, it becomes March 1 which is the end of February 28.
+func moveDate(t time.Time, years, days int, end bool) time.Time {
+	y, m, d := t.Date()
+	elapsed := t.Sub(startOfDay(y, m, d, t.Location()))
+
+	y += years
+	if m == time.February && d == 29 && !isLeapYear(y) && !(end && elapsed == 0) {
+		d = 28
+	}
+
+	return startOfDay(y, m, d+days, t.Location()).Add(elapsed)
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// isDayStart reports whether t is the first moment of its date.
+func isDayStart(t time.Time) bool {
+	y, m, d := t.Date()
+	return startOfDay(y, m, d, t.Location()).Equal(t)
+}
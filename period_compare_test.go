@@ -0,0 +1,243 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestPeriod_LastYear(t *testing.T) {
+	f := rdate.NewPeriodFactory(rdate.WithPeriodStringer(&shortcutPeriodStringer{}))
+
+	testCases := []struct {
+		name     string
+		pivot    time.Time
+		sc       rdate.PeriodShortcut
+		from     time.Time
+		to       time.Time
+		expected string
+	}{
+		{
+			name:     "leap february",
+			pivot:    time.Date(2020, 2, 10, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.PeriodThisMonth,
+			from:     time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2019, 2, 28, 23, 59, 59, 999999999, time.UTC),
+			expected: "12 months ago",
+		},
+		{
+			name:     "quarts",
+			pivot:    time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC),
+			sc:       "prev 2 quarts",
+			from:     time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2019, 6, 30, 23, 59, 59, 999999999, time.UTC),
+			expected: "6 to 5 quarts ago",
+		},
+		{
+			name:     "year",
+			pivot:    time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC),
+			sc:       rdate.PeriodThisYear,
+			from:     time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2019, 12, 31, 23, 59, 59, 999999999, time.UTC),
+			expected: "prev year",
+		},
+		{
+			name:  "week on dates",
+			pivot: time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC),
+			sc:    rdate.PeriodThisWeek,
+			from:  time.Date(2019, 8, 10, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2019, 8, 16, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "february 29",
+			pivot: time.Date(2020, 2, 29, 10, 2, 1, 6, time.UTC),
+			sc:    rdate.PeriodThisDay,
+			from:  time.Date(2019, 2, 28, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2019, 2, 28, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "february 28",
+			pivot: time.Date(2020, 2, 28, 10, 2, 1, 6, time.UTC),
+			sc:    rdate.PeriodThisDay,
+			from:  time.Date(2019, 2, 28, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2019, 2, 28, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:  "to date on february 29",
+			pivot: time.Date(2020, 2, 29, 10, 2, 1, 6, time.UTC),
+			sc:    rdate.PeriodWeekToDate,
+			from:  time.Date(2019, 2, 24, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2019, 2, 28, 10, 2, 1, 6, time.UTC),
+		},
+		{
+			name:  "over february 29",
+			pivot: time.Date(2020, 3, 14, 10, 2, 1, 6, time.UTC),
+			sc:    "prev 28 days",
+			from:  time.Date(2019, 2, 15, 0, 0, 0, 0, time.UTC),
+			to:    time.Date(2019, 3, 13, 23, 59, 59, 999999999, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := f.Require(tc.pivot, tc.sc).LastYear()
+			periodEqual(t, p, tc.from, tc.to)
+			if p.String() != tc.expected {
+				t.Errorf("expected the shortcut %q, got %q", tc.expected, p.String())
+			}
+		})
+	}
+}
+
+func TestPeriod_LastYearByWeekday(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC)
+	f := rdate.NewPeriodFactory(rdate.WithPeriodStringer(&shortcutPeriodStringer{}))
+
+	testCases := []struct {
+		name     string
+		sc       rdate.PeriodShortcut
+		from     time.Time
+		to       time.Time
+		expected string
+	}{
+		{
+			name:     "week",
+			sc:       rdate.PeriodThisWeek,
+			from:     time.Date(2019, 8, 12, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2019, 8, 18, 23, 59, 59, 999999999, time.UTC),
+			expected: "52 weeks ago",
+		},
+		{
+			name:     "days",
+			sc:       "prev 7 days",
+			from:     time.Date(2019, 8, 6, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2019, 8, 12, 23, 59, 59, 999999999, time.UTC),
+			expected: "371 to 365 days ago",
+		},
+		{
+			name: "month",
+			sc:   rdate.PeriodThisMonth,
+			from: time.Date(2019, 8, 3, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2019, 9, 2, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name: "to date",
+			sc:   rdate.PeriodMonthToDate,
+			from: time.Date(2019, 8, 3, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2019, 8, 13, 10, 2, 1, 6, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := f.Require(pivot, tc.sc).LastYearByWeekday()
+			periodEqual(t, p, tc.from, tc.to)
+			if p.String() != tc.expected {
+				t.Errorf("expected the shortcut %q, got %q", tc.expected, p.String())
+			}
+			if p.From().Time().Weekday() != f.Require(pivot, tc.sc).From().Time().Weekday() {
+				t.Errorf("the weekdays don't line up")
+			}
+		})
+	}
+}
+
+func TestPeriod_PrevEqualLength(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC)
+	f := rdate.NewPeriodFactory(rdate.WithPeriodStringer(&shortcutPeriodStringer{}))
+
+	testCases := []struct {
+		name     string
+		sc       rdate.PeriodShortcut
+		from     time.Time
+		to       time.Time
+		expected string
+	}{
+		{
+			name: "month",
+			sc:   rdate.PeriodThisMonth,
+			from: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2020, 7, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "week",
+			sc:       rdate.PeriodThisWeek,
+			from:     time.Date(2020, 8, 3, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 8, 9, 23, 59, 59, 999999999, time.UTC),
+			expected: "prev week",
+		},
+		{
+			name: "to date",
+			sc:   rdate.PeriodMonthToDate,
+			from: time.Date(2020, 7, 21, 13, 57, 58, 999999993, time.UTC),
+			to:   time.Date(2020, 7, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name: "quart",
+			sc:   rdate.PeriodPrevQuart,
+			from: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:   time.Date(2020, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := f.Require(pivot, tc.sc).PrevEqualLength()
+			periodEqual(t, p, tc.from, tc.to)
+			if p.String() != tc.expected {
+				t.Errorf("expected the shortcut %q, got %q", tc.expected, p.String())
+			}
+		})
+	}
+}
+
+func TestPeriod_CompareChained(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC)
+	f := rdate.NewPeriodFactory(rdate.WithPeriodStringer(&shortcutPeriodStringer{}))
+
+	p := f.Require(pivot, "this 3 months").LastYear().LastYear()
+	periodEqual(t, p,
+		time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 8, 31, 23, 59, 59, 999999999, time.UTC))
+	if p.String() != "26 to 24 months ago" {
+		t.Errorf("expected the shortcut %q, got %q", "26 to 24 months ago", p.String())
+	}
+	periodEqual(t, p.Next(1),
+		time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 11, 30, 23, 59, 59, 999999999, time.UTC))
+
+	p = f.Require(pivot, "prev 2 weeks").LastYearByWeekday().PrevEqualLength()
+	periodEqual(t, p,
+		time.Date(2019, 7, 15, 0, 0, 0, 0, time.UTC),
+		time.Date(2019, 7, 28, 23, 59, 59, 999999999, time.UTC))
+	if p.String() != "56 to 55 weeks ago" {
+		t.Errorf("expected the shortcut %q, got %q", "56 to 55 weeks ago", p.String())
+	}
+}
+
+func TestPeriod_PrevEqualLengthDST(t *testing.T) {
+	loc := loadLocation(t, "Europe/London")
+	pivot := time.Date(2020, 4, 10, 10, 2, 1, 6, loc)
+
+	f := rdate.NewPeriodFactory(rdate.WithIntervalMode(rdate.IntervalHalfOpen))
+
+	periodEqual(t, f.Require(pivot, rdate.PeriodThisMonth).PrevEqualLength(),
+		time.Date(2020, 3, 2, 0, 0, 0, 0, loc),
+		time.Date(2020, 4, 1, 0, 0, 0, 0, loc))
+
+	periodEqual(t, f.Require(pivot, rdate.PeriodThisYear).LastYear(),
+		time.Date(2019, 1, 1, 0, 0, 0, 0, loc),
+		time.Date(2020, 1, 1, 0, 0, 0, 0, loc))
+}
+
+func TestPeriod_CompareZero(t *testing.T) {
+	var p rdate.Period
+	if !p.LastYear().IsZero() || !p.LastYearByWeekday().IsZero() || !p.PrevEqualLength().IsZero() {
+		t.Errorf("expected zero-values")
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// periodExpr is a parsed period shortcut.
+// The period covers the units from first to last offsets inclusive,
+// the offsets are relative to the unit the pivot belongs to
+// (see timeExpr).
+type periodExpr struct {
+	first int
+	last  int
+	unit  unit
+}
+
+// parsePeriodExpr parses a period shortcut of the following grammar:
+//
+//	shortcut = offset
+//	         | ("this" | "prev" | "next") number units
+//	         | number "to" number units "ago"
+//	         | "in" number "to" number units
+//	offset   = see parseTimeExpr
+//
+// A period of N units is a sequence of whole units:
+// "this N units" ends with the unit the pivot belongs to,
+// "prev N units" ends with the previous unit and
+// "next N units" starts with the next unit.
+// A range covers the units between two offsets inclusive,
+// e.g. "6 to 4 months ago" or "in 4 to 6 months", the first offset
+// must be the farther one from the pivot in the past and the nearer one
+// in the future, so the ranges are written the way they are read.
+// The offset 0 is the unit the pivot belongs to, e.g. "in 0 to 2 months"
+// is this month and the next two ones.
+func parsePeriodExpr(sc PeriodShortcut) (e periodExpr, ok bool) {
+	words := strings.Fields(string(sc))
+
+	if len(words) > 2 && isDirection(words[0]) {
+		if n, err := strconv.Atoi(words[1]); err == nil {
+			return parseUnitsPeriodExpr(words[0], words[1], n, words[2:])
+		}
+	}
+
+	if e, ok := parseRangePeriodExpr(words); ok {
+		return e, true
+	}
+
+	offset, u, ok := parseOffset(words)
+	if !ok {
+		return periodExpr{}, false
+	}
+
+	return periodExpr{first: offset, last: offset, unit: u}, true
+}
+
+// shortcut formats the expression back to a period shortcut,
+// e.g. "prev month", "3 months ago", "next 2 quarts" or "6 to 4 months ago".
+// The ranges of units the grammar can't express (the ones from the past
+// to the future, e.g. the months from the previous to the next one)
+// have no shortcut, so ok is false.
+func (e periodExpr) shortcut() (sc PeriodShortcut, ok bool) {
+	n := e.last - e.first + 1
+
+	switch {
+	case n == 1:
+		return PeriodShortcut(formatOffset(e.first, e.unit)), true
+	case e.last == 0:
+		return PeriodShortcut("this " + formatNumberOfUnits(n, e.unit)), true
+	case e.last == -1:
+		return PeriodShortcut("prev " + formatNumberOfUnits(n, e.unit)), true
+	case e.first == 1:
+		return PeriodShortcut("next " + formatNumberOfUnits(n, e.unit)), true
+	case e.last < 0:
+		return PeriodShortcut(strconv.Itoa(-e.first) + " to " +
+			formatNumberOfUnits(-e.last, e.unit) + " ago"), true
+	case e.first >= 0:
+		return PeriodShortcut("in " + strconv.Itoa(e.first) + " to " +
+			formatNumberOfUnits(e.last, e.unit)), true
+	}
+
+	return "", false
+}
+
+// parseRangePeriodExpr parses the ranges of units (see parsePeriodExpr).
+func parseRangePeriodExpr(words []string) (e periodExpr, ok bool) {
+	past := len(words) > 0 && words[len(words)-1] == "ago"
+	switch {
+	case past:
+		words = words[:len(words)-1]
+	case len(words) > 0 && words[0] == "in":
+		words = words[1:]
+	default:
+		return periodExpr{}, false
+	}
+
+	if len(words) < 4 || words[1] != "to" {
+		return periodExpr{}, false
+	}
+
+	a, ok := parseRangeNumber(words[0])
+	if !ok {
+		return periodExpr{}, false
+	}
+
+	b, ok := parseRangeNumber(words[2])
+	if !ok {
+		return periodExpr{}, false
+	}
+
+	e.unit, ok = parseUnit(words[3:], true)
+	if !ok {
+		return periodExpr{}, false
+	}
+
+	if past {
+		e.first, e.last = -a, -b
+	} else {
+		e.first, e.last = a, b
+	}
+
+	if e.first >= e.last {
+		return periodExpr{}, false
+	}
+
+	return e, true
+}
+
+func parseRangeNumber(word string) (n int, ok bool) {
+	n, err := strconv.Atoi(word)
+	if err != nil || n < 0 || n > maxUnits || word[0] == '+' {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func parseUnitsPeriodExpr(direction, number string, n int,
+	words []string) (e periodExpr, ok bool) {
+	if n < 1 || n > maxUnits || number[0] == '+' {
+		return periodExpr{}, false
+	}
+
+	e.unit, ok = parseUnit(words, n != 1)
+	if !ok {
+		return periodExpr{}, false
+	}
+
+	switch direction {
+	case "this":
+		e.first, e.last = -(n - 1), 0
+	case "prev":
+		e.first, e.last = -n, -1
+	case "next":
+		e.first, e.last = 1, n
+	}
+
+	return e, true
+}
+
+func isDirection(word string) bool {
+	return word == "this" || word == "prev" || word == "next"
+}
+
+// periodRuleExpr calculates a parsed period shortcut by using the time factory,
+// so the result is consistent with the time rules of the factory.
+type periodRuleExpr struct {
+	sc PeriodShortcut
+	e  periodExpr
+}
+
+func (p *periodRuleExpr) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	from, to, _ = p.resolve(pivot, tf)
+	return from, to
+}
+
+func (p *periodRuleExpr) resolve(pivot time.Time, tf TimeFactory) (from, to Time, err error) {
+	start := timeExpr{anchor: anchorStart, offset: p.e.first, unit: p.e.unit}
+	end := timeExpr{anchor: anchorEnd, offset: p.e.last, unit: p.e.unit}
+
+	return resolveBounds(pivot, tf, start.shortcut(), end.shortcut())
+}
+
+func (p *periodRuleExpr) Shortcut() PeriodShortcut { return p.sc }
+
+var trailingUnitNames = map[TrailingUnit]string{
+	TrailingMinute: "minute",
+	TrailingHour:   "hour",
+	TrailingDay:    "day",
+	TrailingWeek:   "week",
+}
+
+// parseTrailing parses a shortcut of a trailing window:
+//
+//	shortcut = "last" number trailing-units ["including today"]
+//	trailing-unit = "minute" | "hour" | "day" | "week"
+func parseTrailing(sc PeriodShortcut) (PeriodRule, bool) {
+	words := strings.Fields(string(sc))
+	if len(words) < 3 || words[0] != "last" {
+		return nil, false
+	}
+
+	includeToday := false
+	if n := len(words); n > 4 && words[n-2] == "including" && words[n-1] == "today" {
+		includeToday = true
+		words = words[:n-2]
+	}
+
+	if len(words) != 3 {
+		return nil, false
+	}
+
+	n, err := strconv.Atoi(words[1])
+	if err != nil || n < 1 || words[1][0] == '+' {
+		return nil, false
+	}
+
+	for u, s := range trailingUnitNames {
+		if words[2] == s || (n != 1 && words[2] == s+"s") {
+			r, err := NewTrailingPeriodRule(sc, n, u, includeToday)
+			return r, err == nil
+		}
+	}
+
+	return nil, false
+}
+
+// parseToDate parses a shortcut of a to-date period:
+//
+//	shortcut = (unit | offset) "to date"
+//	offset   = see parseTimeExpr
+func parseToDate(sc PeriodShortcut) (PeriodRule, bool) {
+	words := strings.Fields(string(sc))
+	n := len(words)
+	if n < 3 || words[n-2] != "to" || words[n-1] != "date" {
+		return nil, false
+	}
+
+	words = words[:n-2]
+
+	if u, ok := parseUnit(words, false); ok {
+		return &periodRuleToDate{sc: sc, offset: 0, unit: u}, true
+	}
+
+	offset, u, ok := parseOffset(words)
+	if !ok {
+		return nil, false
+	}
+
+	return &periodRuleToDate{sc: sc, offset: offset, unit: u}, true
+}
@@ -0,0 +1,277 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestPeriodFactory_grammar(t *testing.T) {
+	testCases := []struct {
+		name         string
+		pivot        time.Time
+		sc           rdate.PeriodShortcut
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{
+			name:         "Prev3Months",
+			pivot:        time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+			sc:           "prev 3 months",
+			expectedFrom: time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "This2Quarts",
+			pivot:        time.Date(2020, 2, 11, 0, 2, 1, 6, time.UTC),
+			sc:           "this 2 quarts",
+			expectedFrom: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Next2Weeks",
+			pivot:        time.Date(2020, 8, 9, 0, 2, 1, 6, time.UTC),
+			sc:           "next 2 weeks",
+			expectedFrom: time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 8, 23, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Prev7Days",
+			pivot:        time.Date(2020, 3, 3, 0, 2, 1, 6, time.UTC),
+			sc:           "prev 7 days",
+			expectedFrom: time.Date(2020, 2, 25, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 2, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Prev2HalfYears",
+			pivot:        time.Date(2020, 3, 3, 0, 2, 1, 6, time.UTC),
+			sc:           "prev 2 half years",
+			expectedFrom: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "This3Years",
+			pivot:        time.Date(2020, 3, 3, 0, 2, 1, 6, time.UTC),
+			sc:           "this 3 years",
+			expectedFrom: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "6To4MonthsAgo",
+			pivot:        time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+			sc:           "6 to 4 months ago",
+			expectedFrom: time.Date(2019, 9, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 11, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "In2To3Quarts",
+			pivot:        time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+			sc:           "in 2 to 3 quarts",
+			expectedFrom: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "In0To1Month",
+			pivot:        time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+			sc:           "in 0 to 1 month",
+			expectedFrom: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 4, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "2MonthsAgo",
+			pivot:        time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+			sc:           "2 months ago",
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 1, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "In2Quarts",
+			pivot:        time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+			sc:           "in 2 quarts",
+			expectedFrom: time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 9, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Last15Minutes",
+			pivot:        time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+			sc:           "last 15 minutes",
+			expectedFrom: time.Date(2020, 3, 30, 23, 47, 1, 6, time.UTC),
+			expectedTo:   time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last1Hour",
+			pivot:        time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+			sc:           "last 1 hour",
+			expectedFrom: time.Date(2020, 3, 30, 23, 2, 1, 6, time.UTC),
+			expectedTo:   time.Date(2020, 3, 31, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last14Days",
+			pivot:        time.Date(2020, 3, 3, 0, 2, 1, 6, time.UTC),
+			sc:           "last 14 days",
+			expectedFrom: time.Date(2020, 2, 18, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 2, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Last1Day",
+			pivot:        time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+			sc:           "last 1 day",
+			expectedFrom: time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 2, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Last1DayIncludingToday",
+			pivot:        time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+			sc:           "last 1 day including today",
+			expectedFrom: time.Date(2020, 3, 3, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last2DaysIncludingToday",
+			pivot:        time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+			sc:           "last 2 days including today",
+			expectedFrom: time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last2WeeksIncludingToday",
+			pivot:        time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+			sc:           "last 2 weeks including today",
+			expectedFrom: time.Date(2020, 2, 19, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PrevMonthToDate(clamped)",
+			pivot:        time.Date(2020, 3, 31, 10, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodPrevMonthToDate,
+			expectedFrom: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "2YearsAgoToDate",
+			pivot:        time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+			sc:           "2 years ago to date",
+			expectedFrom: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2018, 3, 4, 10, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "DayToDate",
+			pivot:        time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+			sc:           "day to date",
+			expectedFrom: time.Date(2020, 3, 3, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 3, 10, 2, 1, 6, time.UTC),
+		},
+	}
+
+	f := rdate.NewPeriodFactory()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := f.Make(tc.pivot, tc.sc)
+			if !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			periodEqual(t, actual, tc.expectedFrom, tc.expectedTo)
+		})
+	}
+}
+
+func TestPeriodFactory_grammarMatchesRules(t *testing.T) {
+	testCases := []struct {
+		sc       rdate.PeriodShortcut
+		expected rdate.PeriodShortcut
+	}{
+		{sc: "this 1 day", expected: rdate.PeriodThisDay},
+		{sc: "prev 1 day", expected: rdate.PeriodPrevDay},
+		{sc: "next 1 day", expected: rdate.PeriodNextDay},
+		{sc: "this 1 week", expected: rdate.PeriodThisWeek},
+		{sc: "prev 1 week", expected: rdate.PeriodPrevWeek},
+		{sc: "next 1 week", expected: rdate.PeriodNextWeek},
+		{sc: "this 1 month", expected: rdate.PeriodThisMonth},
+		{sc: "prev 1 month", expected: rdate.PeriodPrevMonth},
+		{sc: "next 1 month", expected: rdate.PeriodNextMonth},
+		{sc: "this 1 quart", expected: rdate.PeriodThisQuart},
+		{sc: "prev 1 quart", expected: rdate.PeriodPrevQuart},
+		{sc: "next 1 quart", expected: rdate.PeriodNextQuart},
+		{sc: "this 1 half year", expected: rdate.PeriodThisHalfYear},
+		{sc: "prev 1 half year", expected: rdate.PeriodPrevHalfYear},
+		{sc: "next 1 half year", expected: rdate.PeriodNextHalfYear},
+		{sc: "this 1 year", expected: rdate.PeriodThisYear},
+		{sc: "prev 1 year", expected: rdate.PeriodPrevYear},
+		{sc: "next 1 year", expected: rdate.PeriodNextYear},
+		{sc: "1 month ago", expected: rdate.PeriodPrevMonth},
+		{sc: "in 1 month", expected: rdate.PeriodNextMonth},
+	}
+
+	tf := rdate.NewTimeFactory()
+	tf.SetStartOfWeek(rdate.StartOfWeekSunday)
+
+	f := rdate.NewPeriodFactory()
+	f.SetTimeFactory(tf)
+
+	pivot := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 800; i++ {
+		ts := pivot.AddDate(0, 0, i)
+
+		for _, tc := range testCases {
+			actual, ok := f.Make(ts, tc.sc)
+			if !ok {
+				t.Fatalf("'%s' expected ok but it isn't", tc.sc)
+			}
+
+			expected := f.Require(ts, tc.expected)
+			periodEqual(t, actual, expected.From().Time(), expected.To().Time())
+		}
+	}
+}
+
+func TestPeriodFactory_grammarFails(t *testing.T) {
+	testCases := []rdate.PeriodShortcut{
+		"",
+		"prev",
+		"prev 0 months",
+		"prev -2 months",
+		"prev +2 months",
+		"prev 3 monthss",
+		"last 3 months",
+		"last 0 days",
+		"last 7 days including",
+		"last days",
+		"last 7 days excluding today",
+		"to date",
+		"decade to date",
+		"prev to date",
+		"in 3 months ago",
+		"4 to 6 months ago",
+		"in 6 to 4 months",
+		"3 to 3 months ago",
+		"in 2 to 3 months ago",
+		"6 to 4 months",
+		"6 to months ago",
+		"this 2 decades",
+		"start prev month",
+		"prev 10001 days",
+		"last 1429 weeks",
+		"last 9223372036854775807 minutes",
+	}
+
+	f := rdate.NewPeriodFactory()
+
+	for _, sc := range testCases {
+		t.Run(string(sc), func(t *testing.T) {
+			actual, ok := f.Make(time.Now(), sc)
+			if ok {
+				t.Errorf("expected ok = false but it's true")
+			}
+			if !actual.IsZero() {
+				t.Errorf("expected a zero-value but it isn't")
+			}
+		})
+	}
+}
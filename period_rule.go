@@ -4,7 +4,47 @@
 
 package rdate
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
+
+// boundsResolver is implemented by the period rules of the package
+// which report why they can't calculate the bounds.
+type boundsResolver interface {
+	resolve(pivot time.Time, tf TimeFactory) (from, to Time, err error)
+}
+
+// calculateBounds calculates the bounds of the period by the rule.
+// An error is returned if the rule reports it (see boundsResolver)
+// or any of the bounds is a zero-value of Time.
+func calculateBounds(r PeriodRule, pivot time.Time, tf TimeFactory,
+	sc PeriodShortcut) (from, to Time, err error) {
+	if br, ok := r.(boundsResolver); ok {
+		return br.resolve(pivot, tf)
+	}
+
+	from, to = r.Calculate(pivot, tf)
+	if from.IsZero() || to.IsZero() {
+		return Time{}, Time{}, noResultError(string(sc), pivot)
+	}
+
+	return from, to, nil
+}
+
+// resolveBounds makes the bounds of a period by the time shortcuts
+// (see resolveTime).
+func resolveBounds(pivot time.Time, tf TimeFactory, start, end TimeShortcut) (from, to Time, err error) {
+	if from, err = resolveTime(tf, pivot, start); err != nil {
+		return Time{}, Time{}, err
+	}
+
+	if to, err = resolveTime(tf, pivot, end); err != nil {
+		return Time{}, Time{}, err
+	}
+
+	return from, to, nil
+}
 
 type PeriodRule interface {
 	Calculate(pivot time.Time, tf TimeFactory) (from, to Time)
@@ -118,3 +158,235 @@ func (p *periodRuleThisYear) Calculate(pivot time.Time, tf TimeFactory) (from, t
 }
 
 func (p *periodRuleThisYear) Shortcut() PeriodShortcut { return PeriodThisYear }
+
+type periodRuleNextDay struct{}
+
+func (p *periodRuleNextDay) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfNextDay),
+		tf.Require(pivot, TimeEndOfNextDay)
+}
+
+func (p *periodRuleNextDay) Shortcut() PeriodShortcut { return PeriodNextDay }
+
+type periodRuleNextWeek struct{}
+
+func (p *periodRuleNextWeek) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfNextWeek),
+		tf.Require(pivot, TimeEndOfNextWeek)
+}
+
+func (p *periodRuleNextWeek) Shortcut() PeriodShortcut { return PeriodNextWeek }
+
+type periodRuleNextMonth struct{}
+
+func (p *periodRuleNextMonth) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfNextMonth),
+		tf.Require(pivot, TimeEndOfNextMonth)
+}
+
+func (p *periodRuleNextMonth) Shortcut() PeriodShortcut { return PeriodNextMonth }
+
+type periodRuleNextQuart struct{}
+
+func (p *periodRuleNextQuart) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfNextQuart),
+		tf.Require(pivot, TimeEndOfNextQuart)
+}
+
+func (p *periodRuleNextQuart) Shortcut() PeriodShortcut { return PeriodNextQuart }
+
+type periodRuleNextHalfYear struct{}
+
+func (p *periodRuleNextHalfYear) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfNextHalfYear),
+		tf.Require(pivot, TimeEndOfNextHalfYear)
+}
+
+func (p *periodRuleNextHalfYear) Shortcut() PeriodShortcut { return PeriodNextHalfYear }
+
+type periodRuleNextYear struct{}
+
+func (p *periodRuleNextYear) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfNextYear),
+		tf.Require(pivot, TimeEndOfNextYear)
+}
+
+func (p *periodRuleNextYear) Shortcut() PeriodShortcut { return PeriodNextYear }
+
+type periodRuleThisISOYear struct{}
+
+func (p *periodRuleThisISOYear) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfThisISOYear),
+		tf.Require(pivot, TimeEndOfThisISOYear)
+}
+
+func (p *periodRuleThisISOYear) Shortcut() PeriodShortcut { return PeriodThisISOYear }
+
+type periodRulePrevISOYear struct{}
+
+func (p *periodRulePrevISOYear) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfPrevISOYear),
+		tf.Require(pivot, TimeEndOfPrevISOYear)
+}
+
+func (p *periodRulePrevISOYear) Shortcut() PeriodShortcut { return PeriodPrevISOYear }
+
+type periodRuleNextISOYear struct{}
+
+func (p *periodRuleNextISOYear) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfNextISOYear),
+		tf.Require(pivot, TimeEndOfNextISOYear)
+}
+
+func (p *periodRuleNextISOYear) Shortcut() PeriodShortcut { return PeriodNextISOYear }
+
+type periodRulePrevBusinessDay struct{}
+
+func (p *periodRulePrevBusinessDay) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfPrevBusinessDay),
+		tf.Require(pivot, TimeEndOfPrevBusinessDay)
+}
+
+func (p *periodRulePrevBusinessDay) Shortcut() PeriodShortcut { return PeriodPrevBusinessDay }
+
+type periodRuleNextBusinessDay struct{}
+
+func (p *periodRuleNextBusinessDay) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	return tf.Require(pivot, TimeStartOfNextBusinessDay),
+		tf.Require(pivot, TimeEndOfNextBusinessDay)
+}
+
+func (p *periodRuleNextBusinessDay) Shortcut() PeriodShortcut { return PeriodNextBusinessDay }
+
+// TrailingUnit is a unit of the length of a trailing window
+// (see NewTrailingPeriodRule).
+type TrailingUnit int8
+
+const (
+	TrailingMinute TrailingUnit = iota + 1
+	TrailingHour
+	TrailingDay
+	TrailingWeek
+)
+
+func (u TrailingUnit) valid() bool {
+	return u >= TrailingMinute && u <= TrailingWeek
+}
+
+// maxTrailing returns the max length of a trailing window of the unit,
+// it's maxUnits days.
+func maxTrailing(u TrailingUnit) int {
+	switch u {
+	case TrailingMinute:
+		return maxUnits * 24 * 60
+	case TrailingHour:
+		return maxUnits * 24
+	case TrailingWeek:
+		return maxUnits / 7
+	}
+
+	return maxUnits
+}
+
+type periodRuleTrailing struct {
+	sc           PeriodShortcut
+	n            int
+	u            TrailingUnit
+	includeToday bool
+}
+
+// NewTrailingPeriodRule creates a rule of a window which is measured back
+// from the pivot and isn't aligned to weeks, months and so on.
+//
+// Windows of minutes and hours are exact: the period is
+// [pivot - n units, pivot], e.g. the last 24 hours.
+//
+// Windows of days and weeks consist of whole days: n days (or 7*n days for weeks)
+// which end with the previous day, e.g. the last 7 days are the 7 whole days
+// ending yesterday. If includeToday is true, the current partial day
+// is included instead of the earliest day, so the period ends with the pivot.
+//
+// An error is returned if the unit is unknown or n isn't in [1, 10000] days
+// (e.g. up to 1428 weeks or 240000 hours).
+func NewTrailingPeriodRule(sc PeriodShortcut, n int, u TrailingUnit,
+	includeToday bool) (PeriodRule, error) {
+	if !u.valid() {
+		return nil, fmt.Errorf("rdate: unknown trailing unit %d", u)
+	}
+	if n < 1 || n > maxTrailing(u) {
+		return nil, fmt.Errorf("rdate: the length of a trailing window must be in [1, %d] %ss, got %d",
+			maxTrailing(u), trailingUnitNames[u], n)
+	}
+
+	return &periodRuleTrailing{sc: sc, n: n, u: u, includeToday: includeToday}, nil
+}
+
+func (p *periodRuleTrailing) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	from, to, _ = p.resolve(pivot, tf)
+	return from, to
+}
+
+func (p *periodRuleTrailing) resolve(pivot time.Time, tf TimeFactory) (from, to Time, err error) {
+	switch p.u {
+	case TrailingMinute, TrailingHour:
+		d := time.Minute
+		if p.u == TrailingHour {
+			d = time.Hour
+		}
+
+		if from, err = resolveTime(tf, pivot.Add(-time.Duration(p.n)*d), TimeAsIs); err != nil {
+			return Time{}, Time{}, err
+		}
+
+		if to, err = resolveTime(tf, pivot, TimeAsIs); err != nil {
+			return Time{}, Time{}, err
+		}
+
+		return from, to, nil
+	}
+
+	days := p.n
+	if p.u == TrailingWeek {
+		days *= 7
+	}
+
+	if p.includeToday {
+		return resolveBounds(pivot, tf, daysAgo(days-1), TimeAsIs)
+	}
+
+	return resolveBounds(pivot, tf, daysAgo(days), TimeEndOfPrevDay)
+}
+
+func (p *periodRuleTrailing) Shortcut() PeriodShortcut { return p.sc }
+
+// daysAgo returns the time shortcut of the start of the day
+// which is n days ago, e.g. "start this day" for 0 and "start prev day" for 1.
+func daysAgo(n int) TimeShortcut {
+	return timeExpr{anchor: anchorStart, offset: -n, unit: unitDay}.shortcut()
+}
+
+// periodRuleToDate is a period from the start of the unit up to the pivot
+// (e.g. month to date). If the offset isn't zero, the period covers
+// the same elapsed span in the unit which is offset units away:
+// the same number of days and the same clock from the start of the unit,
+// but never beyond its end. E.g. "prev month to date" for March 31
+// is the whole February.
+type periodRuleToDate struct {
+	sc     PeriodShortcut
+	offset int
+	unit   unit
+}
+
+func (p *periodRuleToDate) Calculate(pivot time.Time, tf TimeFactory) (from, to Time) {
+	from, to, _ = p.resolve(pivot, tf)
+	return from, to
+}
+
+func (p *periodRuleToDate) resolve(pivot time.Time, tf TimeFactory) (from, to Time, err error) {
+	start := timeExpr{anchor: anchorStart, offset: p.offset, unit: p.unit}
+	end := timeExpr{anchor: anchorAsIs, offset: p.offset, unit: p.unit}
+
+	return resolveBounds(pivot, tf, start.shortcut(), end.shortcut())
+}
+
+func (p *periodRuleToDate) Shortcut() PeriodShortcut { return p.sc }
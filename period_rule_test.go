@@ -101,6 +101,181 @@ func TestPeriodRules_Perform(t *testing.T) {
 			expectedFrom: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
 			expectedTo:   time.Date(2018, 12, 31, 23, 59, 59, 999999999, time.UTC),
 		},
+		{
+			name:         "NextDay",
+			rule:         &periodRuleNextDay{},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 12, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 12, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "NextWeek",
+			rule:         &periodRuleNextWeek{},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 16, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 22, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "NextMonth",
+			rule:         &periodRuleNextMonth{},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 1, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "NextQuart",
+			rule:         &periodRuleNextQuart{},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "NextHalfYear",
+			rule:         &periodRuleNextHalfYear{},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 6, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "NextYear",
+			rule:         &periodRuleNextYear{},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Last15Minutes",
+			rule:         &periodRuleTrailing{n: 15, u: TrailingMinute},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 10, 23, 47, 1, 6, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last24Hours",
+			rule:         &periodRuleTrailing{n: 24, u: TrailingHour},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 10, 0, 2, 1, 6, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last7Days",
+			rule:         &periodRuleTrailing{n: 7, u: TrailingDay},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 4, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 10, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Last7Days(including today)",
+			rule:         &periodRuleTrailing{n: 7, u: TrailingDay, includeToday: true},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 5, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last1Day",
+			rule:         &periodRuleTrailing{n: 1, u: TrailingDay},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 10, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 10, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Last1Day(including today)",
+			rule:         &periodRuleTrailing{n: 1, u: TrailingDay, includeToday: true},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 11, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last2Days(including today)",
+			rule:         &periodRuleTrailing{n: 2, u: TrailingDay, includeToday: true},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 10, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "Last2Weeks",
+			rule:         &periodRuleTrailing{n: 2, u: TrailingWeek},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 11, 27, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 10, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "Last1Week(including today)",
+			rule:         &periodRuleTrailing{n: 1, u: TrailingWeek, includeToday: true},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 5, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "WeekToDate",
+			rule:         &periodRuleToDate{offset: 0, unit: unitWeek},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 9, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "MonthToDate",
+			rule:         &periodRuleToDate{offset: 0, unit: unitMonth},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "QuartToDate",
+			rule:         &periodRuleToDate{offset: 0, unit: unitQuart},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "HalfYearToDate",
+			rule:         &periodRuleToDate{offset: 0, unit: unitHalfYear},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "YearToDate",
+			rule:         &periodRuleToDate{offset: 0, unit: unitYear},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PrevWeekToDate",
+			rule:         &periodRuleToDate{offset: -1, unit: unitWeek},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 12, 2, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 4, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PrevMonthToDate",
+			rule:         &periodRuleToDate{offset: -1, unit: unitMonth},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 11, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PrevQuartToDate",
+			rule:         &periodRuleToDate{offset: -1, unit: unitQuart},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 9, 10, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PrevHalfYearToDate",
+			rule:         &periodRuleToDate{offset: -1, unit: unitHalfYear},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 6, 13, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PrevYearToDate",
+			rule:         &periodRuleToDate{offset: -1, unit: unitYear},
+			date:         time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expectedFrom: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2018, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
 	}
 
 	tf := NewTimeFactory()
@@ -119,3 +294,41 @@ func TestPeriodRules_Perform(t *testing.T) {
 		})
 	}
 }
+
+func TestNewTrailingPeriodRule(t *testing.T) {
+	pivot := time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC)
+
+	r, err := NewTrailingPeriodRule("yesterday", 1, TrailingDay, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	from, to := r.Calculate(pivot, NewTimeFactory())
+	if expected := time.Date(2019, 12, 10, 0, 0, 0, 0, time.UTC); !from.Time().Equal(expected) {
+		t.Errorf("Period.From = %s; expected %s", from.Time(), expected)
+	}
+	if expected := time.Date(2019, 12, 10, 23, 59, 59, 999999999, time.UTC); !to.Time().Equal(expected) {
+		t.Errorf("Period.To = %s; expected %s", to.Time(), expected)
+	}
+
+	testCases := []struct {
+		name string
+		n    int
+		u    TrailingUnit
+	}{
+		{name: "zero", n: 0, u: TrailingDay},
+		{name: "negative", n: -7, u: TrailingDay},
+		{name: "too long", n: 1429, u: TrailingWeek},
+		{name: "overflow", n: 1 << 30, u: TrailingMinute},
+		{name: "zero unit", n: 7},
+		{name: "unknown unit", n: 7, u: TrailingUnit(42)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if r, err := NewTrailingPeriodRule("", tc.n, tc.u, false); err == nil || r != nil {
+				t.Errorf("expected an error, got %v", r)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import "time"
+
+// The set operations treat a period as the moments from From (inclusive)
+// to End (exclusive), which is the same as from From to To inclusive
+// for the closed periods. So "prev day" and "this day" don't overlap
+// but they are adjacent, and a zero-value of Period is an empty set.
+//
+// The periods which are made by the operations have no shortcut,
+// they get the stringer and the interval mode of the receiver
+// (or of the other period if the receiver is empty).
+
+// Contains reports whether the moment belongs to the period.
+func (p Period) Contains(t time.Time) bool {
+	return !t.Before(p.from.t) && t.Before(p.end.t)
+}
+
+// ContainsPeriod reports whether every moment of q belongs to the period.
+// An empty q is contained by any period.
+func (p Period) ContainsPeriod(q Period) bool {
+	if q.empty() {
+		return true
+	}
+
+	return !q.from.t.Before(p.from.t) && !q.end.t.After(p.end.t)
+}
+
+// Overlaps reports whether the periods have at least one common moment.
+func (p Period) Overlaps(q Period) bool {
+	return p.from.t.Before(q.end.t) && q.from.t.Before(p.end.t)
+}
+
+// Intersect returns the common part of the periods.
+// If the periods don't overlap, ok is false and the result is a zero-value.
+func (p Period) Intersect(q Period) (r Period, ok bool) {
+	if !p.Overlaps(q) {
+		return Period{}, false
+	}
+
+	from, end := p.from, p.end
+	if q.from.t.After(from.t) {
+		from = q.from
+	}
+	if q.end.t.Before(end.t) {
+		end = q.end
+	}
+
+	return p.span(from, end), true
+}
+
+// Union returns the moments of both periods as one period
+// if they overlap or are adjacent, otherwise it returns both periods
+// sorted by their starts. Empty periods are skipped.
+func (p Period) Union(q Period) []Period {
+	switch {
+	case p.empty() && q.empty():
+		return nil
+	case p.empty():
+		return []Period{q.span(q.from, q.end)}
+	case q.empty():
+		return []Period{p.span(p.from, p.end)}
+	}
+
+	a, b := p, q
+	if b.from.t.Before(a.from.t) {
+		a, b = b, a
+	}
+
+	if b.from.t.After(a.end.t) {
+		return []Period{p.span(a.from, a.end), p.span(b.from, b.end)}
+	}
+
+	end := a.end
+	if b.end.t.After(end.t) {
+		end = b.end
+	}
+
+	return []Period{p.span(a.from, end)}
+}
+
+// Difference returns the moments of the period which don't belong to q.
+// The result consists of none, one or two periods sorted by their starts.
+func (p Period) Difference(q Period) []Period {
+	if p.empty() {
+		return nil
+	}
+
+	if !p.Overlaps(q) {
+		return []Period{p.span(p.from, p.end)}
+	}
+
+	var d []Period
+	if p.from.t.Before(q.from.t) {
+		d = append(d, p.span(p.from, q.from))
+	}
+	if q.end.t.Before(p.end.t) {
+		d = append(d, p.span(q.end, p.end))
+	}
+
+	return d
+}
+
+// empty reports whether the period has no moments.
+func (p Period) empty() bool {
+	return !p.from.t.Before(p.end.t)
+}
+
+// span makes a period from the start to the exclusive end
+// with the stringer, the interval mode, the time factory
+// and the precision of the end of p.
+func (p Period) span(from, end Time) Period {
+	end.precision = p.end.precision
+
+	to := end
+	if p.mode != IntervalHalfOpen {
+		step := time.Nanosecond
+		if end.precision > 0 {
+			step = end.precision
+		}
+
+		to = Time{t: end.t.Add(-step), s: end.s, precision: end.precision}
+	}
+
+	return Period{
+		from: from,
+		to:   to,
+		end:  end,
+		mode: p.mode,
+		s:    p.s,
+		tf:   p.tf,
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestPeriod_Contains(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	for _, mode := range []rdate.IntervalMode{rdate.IntervalClosed, rdate.IntervalHalfOpen} {
+		p := rdate.NewPeriodFactory(rdate.WithIntervalMode(mode)).Require(pivot, rdate.PeriodThisMonth)
+
+		testCases := []struct {
+			t        time.Time
+			expected bool
+		}{
+			{t: pivot, expected: true},
+			{t: time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC), expected: true},
+			{t: time.Date(2020, 8, 31, 23, 59, 59, 999999999, time.UTC), expected: true},
+			{t: time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC), expected: false},
+			{t: time.Date(2020, 7, 31, 23, 59, 59, 999999999, time.UTC), expected: false},
+		}
+
+		for _, tc := range testCases {
+			if actual := p.Contains(tc.t); actual != tc.expected {
+				t.Errorf("mode %d, %s: expected %v, got %v", mode, tc.t, tc.expected, actual)
+			}
+		}
+	}
+
+	if (rdate.Period{}).Contains(pivot) {
+		t.Errorf("a zero-value contains %s", pivot)
+	}
+}
+
+func TestPeriod_ContainsPeriod(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+	f := rdate.NewPeriodFactory()
+
+	month := f.Require(pivot, rdate.PeriodThisMonth)
+
+	testCases := []struct {
+		name     string
+		q        rdate.Period
+		expected bool
+	}{
+		{name: "day", q: f.Require(pivot, rdate.PeriodThisDay), expected: true},
+		{name: "itself", q: month, expected: true},
+		{name: "prev month", q: f.Require(pivot, rdate.PeriodPrevMonth), expected: false},
+		{name: "this quart", q: f.Require(pivot, rdate.PeriodThisQuart), expected: false},
+		{name: "zero", q: rdate.Period{}, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := month.ContainsPeriod(tc.q); actual != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPeriod_Overlaps(t *testing.T) {
+	pivot := time.Date(2020, 8, 1, 0, 2, 1, 6, time.UTC)
+	f := rdate.NewPeriodFactory()
+
+	testCases := []struct {
+		a, b     rdate.PeriodShortcut
+		expected bool
+	}{
+		{a: rdate.PeriodPrevDay, b: rdate.PeriodThisDay, expected: false},
+		{a: rdate.PeriodThisWeek, b: rdate.PeriodThisMonth, expected: true},
+		{a: rdate.PeriodThisWeek, b: rdate.PeriodPrevMonth, expected: true},
+		{a: rdate.PeriodPrevWeek, b: rdate.PeriodThisMonth, expected: false},
+		{a: rdate.PeriodThisYear, b: rdate.PeriodThisDay, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.a)+" "+string(tc.b), func(t *testing.T) {
+			a, b := f.Require(pivot, tc.a), f.Require(pivot, tc.b)
+			if a.Overlaps(b) != tc.expected || b.Overlaps(a) != tc.expected {
+				t.Errorf("expected %v", tc.expected)
+			}
+		})
+	}
+}
+
+func TestPeriod_Intersect(t *testing.T) {
+	pivot := time.Date(2020, 8, 1, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewPeriodFactory()
+
+	p, ok := f.Require(pivot, rdate.PeriodThisWeek).Intersect(f.Require(pivot, rdate.PeriodThisMonth))
+	if !ok {
+		t.Fatalf("expected ok but it isn't")
+	}
+	periodEqual(t, p,
+		time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 2, 23, 59, 59, 999999999, time.UTC))
+
+	if p, ok := f.Require(pivot, rdate.PeriodPrevDay).Intersect(f.Require(pivot, rdate.PeriodThisDay)); ok || !p.IsZero() {
+		t.Errorf("expected no intersection, got %s", p)
+	}
+
+	f.SetIntervalMode(rdate.IntervalHalfOpen)
+
+	p, _ = f.Require(pivot, rdate.PeriodThisWeek).Intersect(f.Require(pivot, rdate.PeriodThisMonth))
+	periodEqual(t, p,
+		time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 3, 0, 0, 0, 0, time.UTC))
+	if p.String() != "2020-08-01 00:00:00 — 2020-08-03 00:00:00" {
+		t.Errorf("unexpected string: %s", p)
+	}
+}
+
+func TestPeriod_Union(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+	f := rdate.NewPeriodFactory()
+
+	testCases := []struct {
+		name     string
+		a, b     rdate.Period
+		expected [][2]time.Time
+	}{
+		{
+			name: "adjacent",
+			a:    f.Require(pivot, rdate.PeriodThisDay),
+			b:    f.Require(pivot, rdate.PeriodPrevDay),
+			expected: [][2]time.Time{{
+				time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 11, 23, 59, 59, 999999999, time.UTC),
+			}},
+		},
+		{
+			name: "overlapping",
+			a:    f.Require(pivot, rdate.PeriodThisWeek),
+			b:    f.Require(pivot, "prev 10 days"),
+			expected: [][2]time.Time{{
+				time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 16, 23, 59, 59, 999999999, time.UTC),
+			}},
+		},
+		{
+			name: "disjoint",
+			a:    f.Require(pivot, rdate.PeriodNextWeek),
+			b:    f.Require(pivot, rdate.PeriodPrevWeek),
+			expected: [][2]time.Time{{
+				time.Date(2020, 8, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 9, 23, 59, 59, 999999999, time.UTC),
+			}, {
+				time.Date(2020, 8, 17, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 23, 23, 59, 59, 999999999, time.UTC),
+			}},
+		},
+		{
+			name: "zero",
+			a:    rdate.Period{},
+			b:    f.Require(pivot, rdate.PeriodThisDay),
+			expected: [][2]time.Time{{
+				time.Date(2020, 8, 11, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 11, 23, 59, 59, 999999999, time.UTC),
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			periodsEqual(t, tc.a.Union(tc.b), tc.expected)
+			periodsEqual(t, tc.b.Union(tc.a), tc.expected)
+		})
+	}
+}
+
+func TestPeriod_Difference(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	tf := rdate.NewTimeFactory(rdate.WithPrecision(time.Second))
+	f := rdate.NewPeriodFactory(rdate.WithTimeFactory(tf))
+
+	testCases := []struct {
+		name     string
+		a, b     rdate.PeriodShortcut
+		expected [][2]time.Time
+	}{
+		{
+			name: "split",
+			a:    rdate.PeriodThisWeek,
+			b:    rdate.PeriodThisDay,
+			expected: [][2]time.Time{{
+				time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 10, 23, 59, 59, 0, time.UTC),
+			}, {
+				time.Date(2020, 8, 12, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 16, 23, 59, 59, 0, time.UTC),
+			}},
+		},
+		{
+			name: "cut",
+			a:    rdate.PeriodThisMonth,
+			b:    rdate.PeriodThisWeek,
+			expected: [][2]time.Time{{
+				time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 9, 23, 59, 59, 0, time.UTC),
+			}, {
+				time.Date(2020, 8, 17, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 31, 23, 59, 59, 0, time.UTC),
+			}},
+		},
+		{
+			name: "disjoint",
+			a:    rdate.PeriodPrevDay,
+			b:    rdate.PeriodThisDay,
+			expected: [][2]time.Time{{
+				time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 10, 23, 59, 59, 0, time.UTC),
+			}},
+		},
+		{
+			name: "covered",
+			a:    rdate.PeriodThisDay,
+			b:    rdate.PeriodThisWeek,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			periodsEqual(t, f.Require(pivot, tc.a).Difference(f.Require(pivot, tc.b)), tc.expected)
+		})
+	}
+}
+
+func periodsEqual(t *testing.T, actual []rdate.Period, expected [][2]time.Time) {
+	t.Helper()
+
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d periods, got %v", len(expected), actual)
+	}
+
+	for i, p := range actual {
+		periodEqual(t, p, expected[i][0], expected[i][1])
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import "time"
+
+// Prev returns the period which is n periods before the period,
+// e.g. the month before "prev month" is "2 months ago" (see Next).
+func (p Period) Prev(n int) Period {
+	return p.shift(-n)
+}
+
+// Next returns the period which is n periods after the period
+// (a negative n moves it back like Prev does).
+//
+// A period of units (e.g. "prev month", "this week" or "prev 2 quarts")
+// is moved by the number of its units and calculated by the time factory
+// of the period factory which made it, so the lengths of months,
+// StartOfWeek and the fiscal year are respected. Its shortcut is rewritten,
+// e.g. "prev month" becomes "2 months ago" and "prev 3 months"
+// becomes "6 to 4 months ago", so the stringers label it right
+// and the moved period is moved by its units again.
+// A to-date period is moved the same way, e.g. "month to date"
+// becomes "prev month to date".
+//
+// A business day period (e.g. "prev business day") is moved
+// by business days. A trailing window (e.g. "last 7 days") is calculated
+// from the pivot moved by the length of the window, days and weeks
+// are moved by the calendar, so the window keeps starting at the start
+// of a day across daylight saving time transitions.
+// Other periods (the ones of your own rules) are moved by their own length.
+// The shortcuts of all of them are kept.
+func (p Period) Next(n int) Period {
+	return p.shift(n)
+}
+
+func (p Period) shift(k int) Period {
+	if k == 0 || p.tf == nil || p.empty() {
+		return p
+	}
+
+	if e, ok := parsePeriodExpr(p.sc); ok {
+		return p.shiftExpr(e, k*(e.last-e.first+1))
+	}
+
+	if r, ok := parseToDate(p.sc); ok {
+		r := r.(*periodRuleToDate)
+		offset := r.offset + k
+		sc := PeriodShortcut(formatOffset(offset, r.unit) + " to date")
+
+		return p.recalculate(&periodRuleToDate{sc: sc, offset: offset, unit: r.unit})
+	}
+
+	if p.sc == PeriodPrevBusinessDay || p.sc == PeriodNextBusinessDay {
+		return p.shiftBusinessDays(k)
+	}
+
+	if r, ok := parseTrailing(p.sc); ok {
+		r := r.(*periodRuleTrailing)
+		p.pivot = r.shiftPivot(p.pivot, k)
+
+		return p.recalculate(r)
+	}
+
+	d := time.Duration(k) * p.end.t.Sub(p.from.t)
+
+	q := p.span(Time{t: p.from.t.Add(d), s: p.from.s}, Time{t: p.end.t.Add(d), s: p.end.s})
+	q.sc = p.sc
+	q.pivot = p.pivot.Add(d)
+
+	return q
+}
+
+// shiftExpr moves the period of the expression by the number of units
+// and rewrites its shortcut.
+func (p Period) shiftExpr(e periodExpr, units int) Period {
+	e.first += units
+	e.last += units
+
+	sc, _ := e.shortcut()
+	return p.recalculate(&periodRuleExpr{sc: sc, e: e})
+}
+
+// recalculate calculates the rule by the pivot and the time factory of the period.
+// The result is a zero-value of Period if the rule can't calculate the bounds.
+func (p Period) recalculate(r PeriodRule) Period {
+	p.sc = r.Shortcut()
+
+	from, to, err := calculateBounds(r, p.pivot, p.tf, p.sc)
+	if err != nil {
+		return Period{}
+	}
+
+	return p.bound(from, to)
+}
+
+// shiftPivot moves the pivot by k lengths of the trailing window.
+// Days and weeks are moved by the calendar, so the clock is kept.
+func (p *periodRuleTrailing) shiftPivot(pivot time.Time, k int) time.Time {
+	switch p.u {
+	case TrailingMinute:
+		return pivot.Add(time.Duration(k*p.n) * time.Minute)
+	case TrailingHour:
+		return pivot.Add(time.Duration(k*p.n) * time.Hour)
+	case TrailingWeek:
+		return pivot.AddDate(0, 0, 7*k*p.n)
+	}
+
+	return pivot.AddDate(0, 0, k*p.n)
+}
+
+// shiftBusinessDays moves a business day period by k business days.
+func (p Period) shiftBusinessDays(k int) Period {
+	sc := TimeStartOfNextBusinessDay
+	if k < 0 {
+		sc, k = TimeStartOfPrevBusinessDay, -k
+	}
+
+	from := p.from
+	for ; k > 0 && !from.t.IsZero(); k-- {
+		from = p.tf.Require(from.t, sc)
+	}
+
+	if from.t.IsZero() {
+		return Period{}
+	}
+
+	return p.bound(from, p.tf.Require(from.t, TimeEndOfThisDay))
+}
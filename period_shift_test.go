@@ -0,0 +1,250 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+type shortcutPeriodStringer struct{}
+
+func (s *shortcutPeriodStringer) String(from, to rdate.Time, sc rdate.PeriodShortcut) string {
+	return string(sc)
+}
+
+func TestPeriod_Shift(t *testing.T) {
+	pivot := time.Date(2020, 3, 31, 10, 2, 1, 6, time.UTC)
+
+	f := rdate.NewPeriodFactory(rdate.WithPeriodStringer(&shortcutPeriodStringer{}))
+
+	testCases := []struct {
+		name     string
+		sc       rdate.PeriodShortcut
+		n        int
+		from     time.Time
+		to       time.Time
+		expected string
+	}{
+		{
+			name:     "month before prev month",
+			sc:       rdate.PeriodPrevMonth,
+			n:        -1,
+			from:     time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 1, 31, 23, 59, 59, 999999999, time.UTC),
+			expected: "2 months ago",
+		},
+		{
+			name:     "back over the year",
+			sc:       rdate.PeriodPrevMonth,
+			n:        -3,
+			from:     time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2019, 11, 30, 23, 59, 59, 999999999, time.UTC),
+			expected: "4 months ago",
+		},
+		{
+			name:     "month after prev month",
+			sc:       rdate.PeriodPrevMonth,
+			n:        1,
+			from:     time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 3, 31, 23, 59, 59, 999999999, time.UTC),
+			expected: "this month",
+		},
+		{
+			name:     "weeks",
+			sc:       rdate.PeriodThisWeek,
+			n:        -3,
+			from:     time.Date(2020, 3, 9, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 3, 15, 23, 59, 59, 999999999, time.UTC),
+			expected: "3 weeks ago",
+		},
+		{
+			name:     "quarts",
+			sc:       "this 2 quarts",
+			n:        1,
+			from:     time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 9, 30, 23, 59, 59, 999999999, time.UTC),
+			expected: "next 2 quarts",
+		},
+		{
+			name:     "range of quarts",
+			sc:       "prev 2 quarts",
+			n:        -1,
+			from:     time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2019, 6, 30, 23, 59, 59, 999999999, time.UTC),
+			expected: "4 to 3 quarts ago",
+		},
+		{
+			name:     "range in the future",
+			sc:       "next 2 months",
+			n:        2,
+			from:     time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 9, 30, 23, 59, 59, 999999999, time.UTC),
+			expected: "in 5 to 6 months",
+		},
+		{
+			name:     "to date",
+			sc:       rdate.PeriodMonthToDate,
+			n:        -1,
+			from:     time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 2, 29, 23, 59, 59, 999999999, time.UTC),
+			expected: "prev month to date",
+		},
+		{
+			name:     "trailing",
+			sc:       rdate.PeriodLast24Hours,
+			n:        -2,
+			from:     time.Date(2020, 3, 28, 10, 2, 1, 6, time.UTC),
+			to:       time.Date(2020, 3, 29, 10, 2, 1, 6, time.UTC),
+			expected: "last 24 hours",
+		},
+		{
+			name:     "business day",
+			sc:       rdate.PeriodPrevBusinessDay,
+			n:        -2,
+			from:     time.Date(2020, 3, 26, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 3, 26, 23, 59, 59, 999999999, time.UTC),
+			expected: "prev business day",
+		},
+		{
+			name:     "business day over the weekend",
+			sc:       rdate.PeriodPrevBusinessDay,
+			n:        -4,
+			from:     time.Date(2020, 3, 24, 0, 0, 0, 0, time.UTC),
+			to:       time.Date(2020, 3, 24, 23, 59, 59, 999999999, time.UTC),
+			expected: "prev business day",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := f.Require(pivot, tc.sc)
+
+			q := p.Next(tc.n)
+			periodEqual(t, q, tc.from, tc.to)
+			if q.String() != tc.expected {
+				t.Errorf("expected the shortcut %q, got %q", tc.expected, q.String())
+			}
+
+			periodEqual(t, p.Prev(-tc.n), tc.from, tc.to)
+		})
+	}
+}
+
+func TestPeriod_ShiftSteps(t *testing.T) {
+	pivot := time.Date(2020, 3, 31, 10, 2, 1, 6, time.UTC)
+
+	p := rdate.RequirePeriod(pivot, rdate.PeriodPrevQuart)
+	for i := 0; i < 5; i++ {
+		p = p.Prev(1)
+	}
+
+	periodEqual(t, p,
+		time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 9, 30, 23, 59, 59, 999999999, time.UTC))
+
+	if p.Next(0) != p {
+		t.Errorf("Next(0) changed the period")
+	}
+}
+
+func TestPeriod_ShiftFiscal(t *testing.T) {
+	fy := rdate.FiscalYear{Month: time.April, Day: 1}
+	pivot := time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewPeriodFactory(
+		rdate.WithTimeFactory(rdate.NewTimeFactory(rdate.WithFiscalYear(fy))),
+		rdate.WithPeriodStringer(rdate.NewFiscalPeriodStringer(fy)),
+		rdate.WithIntervalMode(rdate.IntervalHalfOpen),
+	)
+
+	p := f.Require(pivot, rdate.PeriodPrevQuart).Prev(2)
+	periodEqual(t, p,
+		time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	if p.String() != "FY2021 Q1" {
+		t.Errorf("unexpected string: %s", p)
+	}
+
+	p = f.Require(pivot, "prev 2 quarts").Prev(1)
+	if p.String() != "FY2020 Q4 — FY2021 Q1" {
+		t.Errorf("unexpected string: %s", p)
+	}
+
+	p = p.Prev(1)
+	periodEqual(t, p,
+		time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if p.String() != "FY2020 Q2 — FY2020 Q3" {
+		t.Errorf("unexpected string: %s", p)
+	}
+}
+
+func TestPeriod_ShiftChained(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC)
+
+	f := rdate.NewPeriodFactory(rdate.WithPeriodStringer(&shortcutPeriodStringer{}))
+
+	for _, sc := range []rdate.PeriodShortcut{
+		"prev 3 months", "this 3 months", "next 2 quarts", "prev 7 days", "this 2 half years",
+	} {
+		t.Run(string(sc), func(t *testing.T) {
+			p := f.Require(pivot, sc)
+
+			prev, next := p, p
+			for k := 1; k <= 5; k++ {
+				prev, next = prev.Prev(1), next.Next(1)
+
+				expected := p.Prev(k)
+				periodEqual(t, prev, expected.From().Time(), expected.To().Time())
+				if prev.String() != expected.String() || prev.String() == "" {
+					t.Errorf("expected the shortcut %q, got %q", expected, prev)
+				}
+
+				expected = p.Next(k)
+				periodEqual(t, next, expected.From().Time(), expected.To().Time())
+				if next.String() != expected.String() || next.String() == "" {
+					t.Errorf("expected the shortcut %q, got %q", expected, next)
+				}
+			}
+		})
+	}
+
+	p := f.Require(pivot, "prev 3 months").Prev(1).Prev(1)
+	periodEqual(t, p,
+		time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 1, 31, 23, 59, 59, 999999999, time.UTC))
+	if p.String() != "9 to 7 months ago" {
+		t.Errorf("expected the shortcut %q, got %q", "9 to 7 months ago", p)
+	}
+
+	// the comparison periods keep their units too
+	p = f.Require(pivot, "this 3 months").LastYear()
+	if p.String() != "14 to 12 months ago" {
+		t.Errorf("expected the shortcut %q, got %q", "14 to 12 months ago", p)
+	}
+	periodEqual(t, p.LastYear().Prev(1),
+		time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2018, 5, 31, 23, 59, 59, 999999999, time.UTC))
+}
+
+func TestPeriod_ShiftTrailingDST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	f := rdate.NewPeriodFactory(rdate.WithTimeFactory(rdate.NewTimeFactory(rdate.WithLocation(ny))))
+
+	p := f.Require(time.Date(2021, 3, 20, 0, 0, 0, 0, ny), rdate.PeriodLast7Days).Prev(1)
+	periodEqual(t, p,
+		time.Date(2021, 3, 6, 0, 0, 0, 0, ny),
+		time.Date(2021, 3, 12, 23, 59, 59, 999999999, ny))
+
+	p = f.Require(time.Date(2021, 11, 3, 12, 0, 0, 0, ny), "last 1 week including today").Next(1)
+	periodEqual(t, p,
+		time.Date(2021, 11, 4, 0, 0, 0, 0, ny),
+		time.Date(2021, 11, 10, 12, 0, 0, 0, ny))
+}
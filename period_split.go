@@ -0,0 +1,61 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+// SplitMode defines what happens to the sub-periods which cross the bounds
+// of a split period (see Period Split).
+type SplitMode int8
+
+const (
+	// SplitClip clips the sub-periods at the edges to the bounds of the period,
+	// e.g. the first week of a quart might last a few days only.
+	SplitClip SplitMode = iota
+
+	// SplitWhole keeps the sub-periods at the edges whole,
+	// so the first one might start before the period
+	// and the last one might end after it.
+	SplitWhole
+)
+
+// Split splits the period into the sub-periods of the unit sorted by their starts,
+// e.g. every week of "prev quart" or every day of "this month".
+// The bounds of the units are calculated by the time factory
+// of the period factory which made the period, so StartOfWeek,
+// the fiscal year, the day start and so on are respected.
+// The sub-periods have no shortcut, they get the stringer and the interval mode
+// of the period.
+// The result is nil if the period is empty, the unit is unknown
+// or the period isn't made by a factory.
+func (p Period) Split(u Unit, m SplitMode) []Period {
+	if p.empty() || p.tf == nil || !u.valid() {
+		return nil
+	}
+
+	start, end := unit(u).startShortcut(), unit(u).endShortcut()
+
+	var periods []Period
+	for t := p.from.t; t.Before(p.end.t); {
+		from := p.tf.Require(t, start)
+		to := exclusiveEnd(p.tf.Require(t, end))
+		if !to.t.After(t) {
+			break
+		}
+
+		t = to.t
+
+		if m == SplitClip {
+			if from.t.Before(p.from.t) {
+				from = p.from
+			}
+			if to.t.After(p.end.t) {
+				to = p.end
+			}
+		}
+
+		periods = append(periods, p.span(from, to))
+	}
+
+	return periods
+}
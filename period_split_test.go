@@ -0,0 +1,157 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestPeriod_Split(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	sunday := rdate.NewPeriodFactory(rdate.WithTimeFactory(
+		rdate.NewTimeFactory(rdate.WithStartOfWeek(rdate.StartOfWeekSunday))))
+
+	testCases := []struct {
+		name  string
+		f     rdate.PeriodFactory
+		sc    rdate.PeriodShortcut
+		u     rdate.Unit
+		m     rdate.SplitMode
+		n     int
+		first [2]time.Time
+		last  [2]time.Time
+	}{
+		{
+			name: "weeks of prev quart clipped",
+			f:    rdate.NewPeriodFactory(),
+			sc:   rdate.PeriodPrevQuart,
+			u:    rdate.UnitWeek,
+			m:    rdate.SplitClip,
+			n:    14,
+			first: [2]time.Time{
+				time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 4, 5, 23, 59, 59, 999999999, time.UTC),
+			},
+			last: [2]time.Time{
+				time.Date(2020, 6, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 6, 30, 23, 59, 59, 999999999, time.UTC),
+			},
+		},
+		{
+			name: "weeks of prev quart whole",
+			f:    rdate.NewPeriodFactory(),
+			sc:   rdate.PeriodPrevQuart,
+			u:    rdate.UnitWeek,
+			m:    rdate.SplitWhole,
+			n:    14,
+			first: [2]time.Time{
+				time.Date(2020, 3, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 4, 5, 23, 59, 59, 999999999, time.UTC),
+			},
+			last: [2]time.Time{
+				time.Date(2020, 6, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 7, 5, 23, 59, 59, 999999999, time.UTC),
+			},
+		},
+		{
+			name: "sunday weeks of this month",
+			f:    sunday,
+			sc:   rdate.PeriodThisMonth,
+			u:    rdate.UnitWeek,
+			m:    rdate.SplitWhole,
+			n:    6,
+			first: [2]time.Time{
+				time.Date(2020, 7, 26, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 1, 23, 59, 59, 999999999, time.UTC),
+			},
+			last: [2]time.Time{
+				time.Date(2020, 8, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 9, 5, 23, 59, 59, 999999999, time.UTC),
+			},
+		},
+		{
+			name: "days of this month",
+			f:    rdate.NewPeriodFactory(),
+			sc:   rdate.PeriodThisMonth,
+			u:    rdate.UnitDay,
+			m:    rdate.SplitClip,
+			n:    31,
+			first: [2]time.Time{
+				time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 1, 23, 59, 59, 999999999, time.UTC),
+			},
+			last: [2]time.Time{
+				time.Date(2020, 8, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 31, 23, 59, 59, 999999999, time.UTC),
+			},
+		},
+		{
+			name: "quarts of month to date",
+			f:    rdate.NewPeriodFactory(),
+			sc:   rdate.PeriodMonthToDate,
+			u:    rdate.UnitQuart,
+			m:    rdate.SplitClip,
+			n:    1,
+			first: [2]time.Time{
+				time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+				pivot,
+			},
+			last: [2]time.Time{
+				time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+				pivot,
+			},
+		},
+		{
+			name: "months of this half year half-open",
+			f:    rdate.NewPeriodFactory(rdate.WithIntervalMode(rdate.IntervalHalfOpen)),
+			sc:   rdate.PeriodThisHalfYear,
+			u:    rdate.UnitMonth,
+			m:    rdate.SplitClip,
+			n:    6,
+			first: [2]time.Time{
+				time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+			},
+			last: [2]time.Time{
+				time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			periods := tc.f.Require(pivot, tc.sc).Split(tc.u, tc.m)
+			if len(periods) != tc.n {
+				t.Fatalf("expected %d periods, got %d: %v", tc.n, len(periods), periods)
+			}
+
+			periodEqual(t, periods[0], tc.first[0], tc.first[1])
+			periodEqual(t, periods[len(periods)-1], tc.last[0], tc.last[1])
+
+			for i := 1; i < len(periods); i++ {
+				if !periods[i-1].End().Time().Equal(periods[i].From().Time()) {
+					t.Errorf("periods %v and %v aren't adjacent", periods[i-1], periods[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPeriod_SplitInvalid(t *testing.T) {
+	p := rdate.RequirePeriod(time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC), rdate.PeriodThisMonth)
+
+	if periods := p.Split(rdate.Unit(42), rdate.SplitClip); periods != nil {
+		t.Errorf("expected nil, got %v", periods)
+	}
+
+	if periods := (rdate.Period{}).Split(rdate.UnitDay, rdate.SplitClip); periods != nil {
+		t.Errorf("expected nil, got %v", periods)
+	}
+}
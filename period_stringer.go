@@ -6,6 +6,7 @@ package rdate
 
 import (
 	"fmt"
+	"time"
 )
 
 type PeriodStringer interface {
@@ -19,3 +20,44 @@ type defaultPeriodStringer struct{}
 func (s *defaultPeriodStringer) String(from, to Time, sc PeriodShortcut) string {
 	return fmt.Sprintf("%s — %s", from, to)
 }
+
+type fiscalPeriodStringer struct {
+	fy FiscalYear
+}
+
+// NewFiscalPeriodStringer creates a period stringer which formats periods
+// of fiscal quarts, half years and years (including periods of N units
+// like "prev 2 quarts") as labels, e.g. "FY2021 Q3" or "FY2020 Q4 — FY2021 Q1".
+// Other periods are formatted by DefaultPeriodStringer.
+func NewFiscalPeriodStringer(fy FiscalYear) PeriodStringer {
+	return &fiscalPeriodStringer{fy: fy}
+}
+
+func (s *fiscalPeriodStringer) String(from, to Time, sc PeriodShortcut) string {
+	e, ok := parsePeriodExpr(sc)
+	if !ok {
+		return DefaultPeriodStringer.String(from, to, sc)
+	}
+
+	var label func(t time.Time) string
+	switch e.unit {
+	case unitQuart:
+		label = s.fy.QuartLabel
+	case unitHalfYear:
+		label = s.fy.HalfYearLabel
+	case unitYear:
+		label = s.fy.YearLabel
+	default:
+		return DefaultPeriodStringer.String(from, to, sc)
+	}
+
+	if e.first == e.last {
+		return label(from.Time())
+	}
+
+	// The moment right before the end belongs to the last unit
+	// in both interval modes.
+	last := to.Time().Add(-time.Nanosecond)
+
+	return fmt.Sprintf("%s — %s", label(from.Time()), label(last))
+}
@@ -38,3 +38,37 @@ func TestDefaultPeriodStringer(t *testing.T) {
 		})
 	}
 }
+
+func TestFiscalPeriodStringer(t *testing.T) {
+	fy := rdate.FiscalYear{Month: time.April, Day: 1}
+
+	tf := rdate.NewTimeFactory()
+	tf.SetFiscalYear(fy)
+
+	pf := rdate.NewPeriodFactory()
+	pf.SetTimeFactory(tf)
+	pf.SetStringer(rdate.NewFiscalPeriodStringer(fy))
+
+	pivot := time.Date(2021, 2, 10, 0, 2, 1, 6, time.UTC)
+
+	testCases := []struct {
+		sc       rdate.PeriodShortcut
+		expected string
+	}{
+		{sc: rdate.PeriodThisQuart, expected: "FY2021 Q4"},
+		{sc: rdate.PeriodPrevHalfYear, expected: "FY2021 H1"},
+		{sc: rdate.PeriodNextYear, expected: "FY2022"},
+		{sc: "prev 2 quarts", expected: "FY2021 Q2 — FY2021 Q3"},
+		{sc: rdate.PeriodPrevDay, expected: "2021-02-09 00:00:00 — 2021-02-09 23:59:59"},
+		{sc: rdate.PeriodLast7Days, expected: "2021-02-03 00:00:00 — 2021-02-09 23:59:59"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.sc), func(t *testing.T) {
+			actual := pf.Require(pivot, tc.sc).String()
+			if actual != tc.expected {
+				t.Errorf("expected: '%s', but actual: '%s'", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -112,6 +112,139 @@ func TestDefaultPediodFactory_existenceOfRules(t *testing.T) {
 			expectedFrom: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
 			expectedTo:   time.Date(2018, 12, 31, 23, 59, 59, 999999999, time.UTC),
 		},
+		{
+			name:         "PeriodNextDay",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodNextDay,
+			expectedFrom: time.Date(2019, 12, 12, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 12, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "PeriodNextWeek",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodNextWeek,
+			expectedFrom: time.Date(2019, 12, 16, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 22, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "PeriodNextMonth",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodNextMonth,
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 1, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "PeriodNextQuart",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodNextQuart,
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "PeriodNextHalfYear",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodNextHalfYear,
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 6, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "PeriodNextYear",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodNextYear,
+			expectedFrom: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2020, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "PeriodLast24Hours",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodLast24Hours,
+			expectedFrom: time.Date(2019, 12, 10, 0, 2, 1, 6, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodLast7Days",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodLast7Days,
+			expectedFrom: time.Date(2019, 12, 4, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 10, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "PeriodLast30Days",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodLast30Days,
+			expectedFrom: time.Date(2019, 11, 11, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 10, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:         "PeriodWeekToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodWeekToDate,
+			expectedFrom: time.Date(2019, 12, 9, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodMonthToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodMonthToDate,
+			expectedFrom: time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodQuartToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodQuartToDate,
+			expectedFrom: time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodHalfYearToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodHalfYearToDate,
+			expectedFrom: time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodYearToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodYearToDate,
+			expectedFrom: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodPrevWeekToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodPrevWeekToDate,
+			expectedFrom: time.Date(2019, 12, 2, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 12, 4, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodPrevMonthToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodPrevMonthToDate,
+			expectedFrom: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 11, 11, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodPrevQuartToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodPrevQuartToDate,
+			expectedFrom: time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 9, 10, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodPrevHalfYearToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodPrevHalfYearToDate,
+			expectedFrom: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2019, 6, 13, 0, 2, 1, 6, time.UTC),
+		},
+		{
+			name:         "PeriodPrevYearToDate",
+			pivot:        time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:           rdate.PeriodPrevYearToDate,
+			expectedFrom: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedTo:   time.Date(2018, 12, 11, 0, 2, 1, 6, time.UTC),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -242,6 +375,92 @@ func (s *testPeriodStringer) String(from, to rdate.Time,
 	return "test period stringer"
 }
 
+func TestPeriodFactory_Remove(t *testing.T) {
+	pivot := time.Date(2010, 3, 1, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewPeriodFactory()
+	f.Remove(rdate.PeriodLast7Days, "unknown shortcut")
+
+	if _, ok := f.Make(pivot, rdate.PeriodPrevMonth); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+
+	// the shortcut isn't parsed as a trailing window either
+	for _, sc := range []rdate.PeriodShortcut{rdate.PeriodLast7Days, "prev month", "month to date"} {
+		f.Remove(sc)
+		if _, ok := f.Make(pivot, sc); ok {
+			t.Errorf("%s: expected ok = false but it's true", sc)
+		}
+	}
+	if _, err := f.Resolve(pivot, rdate.PeriodLast7Days); err == nil {
+		t.Errorf("expected an error but it isn't")
+	}
+
+	// the other shortcuts of the grammar are still parsed
+	periodEqual(t, f.Require(pivot, "last 8 days"),
+		time.Date(2010, 2, 21, 0, 0, 0, 0, time.UTC),
+		time.Date(2010, 2, 28, 23, 59, 59, 999999999, time.UTC))
+
+	// a removed shortcut is known again after it's registered
+	f.Extend([]rdate.PeriodRule{&shortcutPeriodRule{sc: rdate.PeriodLast7Days}})
+	if _, ok := f.Make(pivot, rdate.PeriodLast7Days); !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+
+	f.Extend([]rdate.PeriodRule{&testPeriodRule{}})
+	f.Remove("my test period")
+
+	if _, ok := f.Make(pivot, "my test period"); ok {
+		t.Errorf("expected ok = false but it's true")
+	}
+}
+
+func TestPeriodFactory_Clone(t *testing.T) {
+	pivot := time.Date(2010, 3, 1, 0, 2, 1, 6, time.UTC)
+
+	testCases := []struct {
+		name string
+		f    rdate.ConfigurablePeriodFactory
+	}{
+		{name: "safe", f: rdate.NewPeriodFactory()},
+		{name: "nonblocking", f: rdate.NewNonblockingPeriodFactory()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.f.Clone()
+			c.Extend([]rdate.PeriodRule{&testPeriodRule{}})
+			c.Remove(rdate.PeriodPrevMonth)
+			c.SetStringer(&testPeriodStringer{})
+
+			if _, ok := c.Make(pivot, "my test period"); !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+			if _, ok := tc.f.Make(pivot, "my test period"); ok {
+				t.Errorf("expected ok = false but it's true")
+			}
+
+			// "prev month" is removed from the copy only
+			if _, ok := c.Make(pivot, rdate.PeriodPrevMonth); ok {
+				t.Errorf("expected ok = false but it's true")
+			}
+			if _, ok := tc.f.Make(pivot, rdate.PeriodPrevMonth); !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			// and from the copies of the copy
+			cc := c.Clone()
+			if _, ok := cc.Make(pivot, rdate.PeriodPrevMonth); ok {
+				t.Errorf("expected ok = false but it's true")
+			}
+			cc.Remove(rdate.PeriodPrevYear)
+			if _, ok := c.Make(pivot, rdate.PeriodPrevYear); !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+		})
+	}
+}
+
 func TestPeriodFactory_SetStringer(t *testing.T) {
 	expected := []string{
 		"2010-02-22 00:00:00 — 2010-02-28 23:59:59",
@@ -314,6 +533,94 @@ func TestPeriodFactory_SetTimeFactory(t *testing.T) {
 	}
 }
 
+func TestPeriodFactory_dayStart(t *testing.T) {
+	tf := rdate.NewTimeFactory()
+	tf.SetDayStart(6 * time.Hour)
+
+	pf := rdate.NewPeriodFactory()
+	pf.SetTimeFactory(tf)
+
+	p := pf.Require(time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC), rdate.PeriodPrevDay)
+	periodEqual(t, p,
+		time.Date(2020, 8, 9, 6, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 10, 5, 59, 59, 999999999, time.UTC))
+
+	p = pf.Require(time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC), rdate.PeriodThisWeek)
+	periodEqual(t, p,
+		time.Date(2020, 8, 10, 6, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 17, 5, 59, 59, 999999999, time.UTC))
+}
+
+func TestPeriodFactory_dayStartDST(t *testing.T) {
+	ny := loadLocation(t, "America/New_York")
+
+	pf := rdate.NewPeriodFactory(rdate.WithTimeFactory(
+		rdate.NewTimeFactory(rdate.WithDayStart(6 * time.Hour))))
+
+	pivot := time.Date(2021, 3, 14, 8, 30, 0, 0, ny)
+
+	periodEqual(t, pf.Require(pivot, "month to date"),
+		time.Date(2021, 3, 1, 6, 0, 0, 0, ny), pivot)
+	periodEqual(t, pf.Require(pivot, "last 2 days including today"),
+		time.Date(2021, 3, 13, 6, 0, 0, 0, ny), pivot)
+	periodEqual(t, pf.Require(pivot, "last 3 hours"),
+		time.Date(2021, 3, 14, 5, 30, 0, 0, ny), pivot)
+
+	// the second 01:30 of the day when the clocks go back
+	pivot = time.Date(2021, 11, 7, 1, 30, 0, 0, ny).Add(time.Hour)
+
+	periodEqual(t, pf.Require(pivot, "week to date"),
+		time.Date(2021, 11, 1, 6, 0, 0, 0, ny), pivot)
+	periodEqual(t, pf.Require(pivot, "last 2 hours"),
+		time.Date(2021, 11, 7, 0, 30, 0, 0, ny), pivot)
+}
+
+func TestPeriodFactory_SetLocation(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("tzdata is unavailable: %v", err)
+	}
+
+	f := rdate.NewPeriodFactory()
+	f.SetLocation(la)
+
+	p := f.Require(time.Date(2020, 8, 1, 3, 0, 0, 0, time.UTC), rdate.PeriodPrevMonth)
+	periodEqual(t, p,
+		time.Date(2020, 6, 1, 0, 0, 0, 0, la),
+		time.Date(2020, 6, 30, 23, 59, 59, 999999999, la))
+	if p.From().Time().Location() != la || p.To().Time().Location() != la {
+		t.Errorf("expected the period in %s but it isn't", la)
+	}
+}
+
+func TestPeriodFactory_SetLocationOfTimeFactory(t *testing.T) {
+	la := loadLocation(t, "America/Los_Angeles")
+	pivot := time.Date(2020, 8, 1, 3, 0, 0, 0, time.UTC)
+
+	tf := rdate.NewTimeFactory()
+	f := rdate.NewPeriodFactory(rdate.WithTimeFactory(tf))
+	f.SetLocation(la)
+
+	// the time factory follows the period factory
+	timeEqual(t, tf.Require(pivot, rdate.TimeStartOfThisMonth),
+		time.Date(2020, 7, 1, 0, 0, 0, 0, la))
+
+	// and the period factory follows the time factory
+	tf.SetLocation(time.UTC)
+	periodEqual(t, f.Require(pivot, rdate.PeriodThisMonth),
+		time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 31, 23, 59, 59, 999999999, time.UTC))
+
+	frozen := rdate.NewFrozenTimeFactory()
+	ff := rdate.NewFrozenPeriodFactory().WithTimeFactory(frozen).WithLocation(la)
+
+	periodEqual(t, ff.Require(pivot, rdate.PeriodThisMonth),
+		time.Date(2020, 7, 1, 0, 0, 0, 0, la),
+		time.Date(2020, 7, 31, 23, 59, 59, 999999999, la))
+	timeEqual(t, frozen.Require(pivot, rdate.TimeStartOfThisMonth),
+		time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC))
+}
+
 func TestSetDefaultPeriodFactory(t *testing.T) {
 	pivot := time.Date(2010, 3, 1, 0, 2, 1, 6, time.UTC)
 
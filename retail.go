@@ -0,0 +1,252 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetailPattern is the number of weeks in the months of a retail quart.
+type RetailPattern int8
+
+const (
+	Retail445 RetailPattern = iota + 1
+	Retail454
+	Retail544
+)
+
+var retailPatternWeeks = map[RetailPattern][3]int{
+	Retail445: {4, 4, 5},
+	Retail454: {4, 5, 4},
+	Retail544: {5, 4, 4},
+}
+
+// RetailCalendar is a 52/53-week retail calendar.
+//
+// A retail year ends on the EndWeekday which is the nearest to the last day
+// of the EndMonth, so it consists of 52 or 53 whole weeks.
+// Weeks start on the day after the EndWeekday.
+// A retail quart consists of 13 weeks which are split into months
+// by the Pattern. The 53rd week is added to the last month (and so to the last quart
+// and the last half year) of the year.
+type RetailCalendar struct {
+	Pattern    RetailPattern
+	EndMonth   time.Month
+	EndWeekday time.Weekday
+}
+
+// NRFCalendar is the 4-5-4 calendar of the National Retail Federation.
+// A year ends on the Saturday nearest the end of January.
+var NRFCalendar = RetailCalendar{
+	Pattern:    Retail454,
+	EndMonth:   time.January,
+	EndWeekday: time.Saturday,
+}
+
+// validate returns an error if the pattern, the month
+// or the weekday of the calendar is unknown.
+func (c RetailCalendar) validate() error {
+	if _, ok := retailPatternWeeks[c.Pattern]; !ok {
+		return fmt.Errorf("rdate: unknown retail pattern %d", c.Pattern)
+	}
+
+	if c.EndMonth < time.January || c.EndMonth > time.December {
+		return fmt.Errorf("rdate: unknown end month %d of a retail calendar", c.EndMonth)
+	}
+
+	if c.EndWeekday < time.Sunday || c.EndWeekday > time.Saturday {
+		return fmt.Errorf("rdate: unknown end weekday %d of a retail calendar", c.EndWeekday)
+	}
+
+	return nil
+}
+
+// NewRetailTimeFactory creates a time factory which implements the retail calendar
+// for every default shortcut and is safe for concurrent use by multiple goroutines.
+// Days are the calendar ones, weeks, months, quarts, half years and years
+// are the retail ones, e.g. "start prev month" is the start of
+// the previous retail month.
+// An error is returned if the pattern, the end month or the end weekday
+// of the calendar is unknown.
+//
+// The retail rules are registered like the ones of Extend, so SetStartOfWeek
+// and SetFiscalYear replace them: the former brings the calendar weeks back,
+// the latter the fiscal quarts, half years and years.
+func NewRetailTimeFactory(c RetailCalendar) (ConfigurableTimeFactory, error) {
+	f, err := newUnsafeRetailTimeFactory(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSafeTimeFactory(f), nil
+}
+
+// NewNonblockingRetailTimeFactory creates an unsafe time factory
+// which implements the retail calendar (see NewRetailTimeFactory
+// and NewNonblockingTimeFactory).
+func NewNonblockingRetailTimeFactory(c RetailCalendar) (ConfigurableTimeFactory, error) {
+	f, err := newUnsafeRetailTimeFactory(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func newUnsafeRetailTimeFactory(c RetailCalendar) (*unsafeTimeFactory, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	f := newUnsafeTimeFactory(defaultRules, &defaultTimeStringer{})
+	f.Extend(c.rules())
+
+	return f, nil
+}
+
+// NewRetailPeriodFactory creates a period factory which implements
+// the retail calendar for every default shortcut and is safe for concurrent use
+// by multiple goroutines, e.g. "prev month" is the previous retail month.
+// An error is returned if the calendar is invalid (see NewRetailTimeFactory).
+func NewRetailPeriodFactory(c RetailCalendar) (ConfigurablePeriodFactory, error) {
+	tf, err := newUnsafeRetailTimeFactory(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSafePeriodFactory(
+		newUnsafePeriodFactory(defaultPeriodRules, tf, &defaultPeriodStringer{})), nil
+}
+
+// rules returns the week, month, quart, half year and year rules
+// of the retail calendar.
+func (c RetailCalendar) rules() []TimeRule {
+	var rules []TimeRule
+
+	for _, u := range []unit{unitWeek, unitMonth, unitQuart, unitHalfYear, unitYear} {
+		start := &timeRuleRetail{c: c, u: u, anchor: anchorStart}
+		end := &timeRuleRetail{c: c, u: u, anchor: anchorEnd}
+
+		rules = append(rules, start, end)
+
+		for _, offset := range []int{-1, 1} {
+			for _, a := range []anchor{anchorStart, anchorEnd} {
+				e := timeExpr{anchor: a, offset: offset, unit: u}
+				rules = append(rules, &timeRuleExpr{
+					sc:    e.shortcut(),
+					e:     e,
+					start: start,
+					end:   end,
+				})
+			}
+		}
+	}
+
+	return rules
+}
+
+// yearEnd returns the date of the end of the retail year
+// which ends in the given calendar year.
+func (c RetailCalendar) yearEnd(year int, loc *time.Location) time.Time {
+	last := time.Date(year, c.EndMonth+1, 0, 0, 0, 0, 0, loc)
+
+	d := (int(c.EndWeekday) - int(last.Weekday()) + 7) % 7
+	if d > 3 {
+		d -= 7
+	}
+
+	return time.Date(year, c.EndMonth+1, d, 0, 0, 0, 0, loc)
+}
+
+// year returns the date of the first day of the retail year the date belongs to
+// and the number of its weeks (52 or 53).
+func (c RetailCalendar) year(date time.Time) (start time.Time, weeks int) {
+	year := date.Year()
+
+	end := c.yearEnd(year, date.Location())
+	if date.After(end) {
+		year++
+		end = c.yearEnd(year, date.Location())
+	}
+
+	prevEnd := c.yearEnd(year-1, date.Location())
+	if !date.After(prevEnd) {
+		end = prevEnd
+		prevEnd = c.yearEnd(year-2, date.Location())
+	}
+
+	return prevEnd.AddDate(0, 0, 1), civilDays(prevEnd, end) / 7
+}
+
+// segments returns the number of weeks in every unit of the retail year.
+func (c RetailCalendar) segments(u unit, weeks int) []int {
+	var s []int
+
+	switch u {
+	case unitWeek:
+		s = make([]int, weeks)
+		for i := range s {
+			s[i] = 1
+		}
+
+		return s
+	case unitMonth:
+		p := retailPatternWeeks[c.Pattern]
+		s = []int{p[0], p[1], p[2], p[0], p[1], p[2], p[0], p[1], p[2], p[0], p[1], p[2]}
+	case unitQuart:
+		s = []int{13, 13, 13, 13}
+	case unitHalfYear:
+		s = []int{26, 26}
+	case unitYear:
+		s = []int{52}
+	}
+
+	s[len(s)-1] += weeks - 52
+
+	return s
+}
+
+// timeRuleRetail calculates the start or the end of a retail unit
+// the pivot belongs to.
+type timeRuleRetail struct {
+	c      RetailCalendar
+	u      unit
+	anchor anchor
+}
+
+func (r *timeRuleRetail) Calculate(pivot time.Time) time.Time {
+	// The calendar is calculated on the dates in UTC,
+	// so daylight saving time transitions don't affect it.
+	date := time.Date(pivot.Year(), pivot.Month(), pivot.Day(), 0, 0, 0, 0, time.UTC)
+
+	start, weeks := r.c.year(date)
+	week := civilDays(start, date) / 7
+
+	from := 0
+	for _, n := range r.c.segments(r.u, weeks) {
+		if week < from+n {
+			if r.anchor == anchorEnd {
+				return endOfDay(start.Year(), start.Month(), start.Day()+(from+n)*7-1,
+					pivot.Location())
+			}
+
+			return startOfDay(start.Year(), start.Month(), start.Day()+from*7,
+				pivot.Location())
+		}
+
+		from += n
+	}
+
+	return time.Time{}
+}
+
+func (r *timeRuleRetail) Shortcut() TimeShortcut {
+	if r.anchor == anchorEnd {
+		return r.u.endShortcut()
+	}
+
+	return r.u.startShortcut()
+}
@@ -0,0 +1,293 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestRetailTimeFactory(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pivot    time.Time
+		sc       rdate.TimeShortcut
+		expected time.Time
+	}{
+		{
+			name:     "TimeStartOfThisWeek",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisWeek,
+			expected: time.Date(2020, 8, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisWeek",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisWeek,
+			expected: time.Date(2020, 8, 15, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisMonth",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisMonth,
+			expected: time.Date(2020, 8, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisMonth",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisMonth,
+			expected: time.Date(2020, 8, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfPrevMonth",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevMonth,
+			expected: time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextMonth",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextMonth,
+			expected: time.Date(2020, 10, 3, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisQuart",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisQuart,
+			expected: time.Date(2020, 8, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisQuart",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisQuart,
+			expected: time.Date(2020, 10, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisHalfYear",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisHalfYear,
+			expected: time.Date(2020, 8, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisYear",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisYear,
+			expected: time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisYear",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisYear,
+			expected: time.Date(2021, 1, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisMonth(53rd week)",
+			pivot:    time.Date(2024, 2, 1, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisMonth,
+			expected: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisQuart(53rd week)",
+			pivot:    time.Date(2023, 12, 1, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisQuart,
+			expected: time.Date(2024, 2, 3, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextYear(53rd week)",
+			pivot:    time.Date(2024, 2, 1, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextYear,
+			expected: time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfPrevYear(53rd week)",
+			pivot:    time.Date(2024, 2, 4, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevYear,
+			expected: time.Date(2023, 1, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfPrevDay",
+			pivot:    time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevDay,
+			expected: time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	f := newRetailTimeFactory(t, rdate.NRFCalendar)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := f.Make(tc.pivot, tc.sc)
+			if !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			timeEqual(t, actual, tc.expected)
+		})
+	}
+}
+
+func TestRetailTimeFactory_patterns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		c        rdate.RetailCalendar
+		expected []time.Time
+	}{
+		{
+			name: "4-4-5",
+			c: rdate.RetailCalendar{
+				Pattern:    rdate.Retail445,
+				EndMonth:   time.December,
+				EndWeekday: time.Sunday,
+			},
+			expected: []time.Time{
+				time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 1, 27, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 2, 24, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 3, 30, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "5-4-4",
+			c: rdate.RetailCalendar{
+				Pattern:    rdate.Retail544,
+				EndMonth:   time.December,
+				EndWeekday: time.Sunday,
+			},
+			expected: []time.Time{
+				time.Date(2019, 12, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 2, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2020, 3, 30, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newRetailTimeFactory(t, tc.c)
+
+			actual := f.Require(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				rdate.TimeStartOfThisMonth)
+			for _, expected := range tc.expected {
+				timeEqual(t, actual, expected)
+				actual = f.Require(actual.Time(), rdate.TimeStartOfNextMonth)
+			}
+		})
+	}
+}
+
+func TestRetailTimeFactory_continuity(t *testing.T) {
+	units := []struct {
+		start, end, prevEnd rdate.TimeShortcut
+	}{
+		{rdate.TimeStartOfThisWeek, rdate.TimeEndOfThisWeek, rdate.TimeEndOfPrevWeek},
+		{rdate.TimeStartOfThisMonth, rdate.TimeEndOfThisMonth, rdate.TimeEndOfPrevMonth},
+		{rdate.TimeStartOfThisQuart, rdate.TimeEndOfThisQuart, rdate.TimeEndOfPrevQuart},
+		{rdate.TimeStartOfThisHalfYear, rdate.TimeEndOfThisHalfYear, rdate.TimeEndOfPrevHalfYear},
+		{rdate.TimeStartOfThisYear, rdate.TimeEndOfThisYear, rdate.TimeEndOfPrevYear},
+	}
+
+	f := newRetailTimeFactory(t, rdate.NRFCalendar)
+
+	pivot := time.Date(2015, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 4000; i++ {
+		ts := pivot.AddDate(0, 0, i)
+
+		for _, u := range units {
+			start := f.Require(ts, u.start).Time()
+			end := f.Require(ts, u.end).Time()
+
+			if ts.Before(start) || ts.After(end) {
+				t.Fatalf("%s is out of [%s, %s]", ts, start, end)
+			}
+			if start.Weekday() != time.Sunday {
+				t.Fatalf("%s isn't Sunday", start)
+			}
+
+			timeEqual(t, f.Require(ts, u.prevEnd), start.Add(-time.Nanosecond))
+		}
+	}
+}
+
+func TestRetailPeriodFactory(t *testing.T) {
+	f, err := rdate.NewRetailPeriodFactory(rdate.NRFCalendar)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := f.Require(time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC), rdate.PeriodPrevMonth)
+	periodEqual(t, p,
+		time.Date(2020, 7, 5, 0, 0, 0, 0, time.UTC),
+		time.Date(2020, 8, 1, 23, 59, 59, 999999999, time.UTC))
+}
+
+func TestRetailTimeFactory_invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		c    rdate.RetailCalendar
+	}{
+		{name: "zero-value", c: rdate.RetailCalendar{}},
+		{name: "pattern", c: rdate.RetailCalendar{
+			Pattern: 42, EndMonth: time.January, EndWeekday: time.Saturday}},
+		{name: "month", c: rdate.RetailCalendar{
+			Pattern: rdate.Retail454, EndMonth: 13, EndWeekday: time.Saturday}},
+		{name: "weekday", c: rdate.RetailCalendar{
+			Pattern: rdate.Retail454, EndMonth: time.January, EndWeekday: 7}},
+	}
+
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if f, err := rdate.NewRetailTimeFactory(tc.c); err == nil || f != nil {
+				t.Errorf("expected an error but it isn't")
+			}
+			if f, err := rdate.NewNonblockingRetailTimeFactory(tc.c); err == nil || f != nil {
+				t.Errorf("expected an error but it isn't")
+			}
+			if f, err := rdate.NewRetailPeriodFactory(tc.c); err == nil || f != nil {
+				t.Errorf("expected an error but it isn't")
+			}
+
+			f := rdate.NewTimeFactory(rdate.WithRetailCalendar(tc.c))
+			timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisMonth),
+				time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC))
+		})
+	}
+}
+
+func TestRetailTimeFactory_setters(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewTimeFactory(rdate.WithRetailCalendar(rdate.NRFCalendar))
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisWeek),
+		time.Date(2020, 8, 9, 0, 0, 0, 0, time.UTC))
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisYear),
+		time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC))
+
+	// the setters replace the retail rules of their units only
+	f.SetStartOfWeek(rdate.StartOfWeekMonday)
+	f.SetFiscalYear(rdate.FiscalYear{Month: time.April, Day: 1})
+
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisWeek),
+		time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC))
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisYear),
+		time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC))
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisMonth),
+		time.Date(2020, 8, 2, 0, 0, 0, 0, time.UTC))
+}
+
+func newRetailTimeFactory(t *testing.T, c rdate.RetailCalendar) rdate.ConfigurableTimeFactory {
+	t.Helper()
+
+	f, err := rdate.NewRetailTimeFactory(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return f
+}
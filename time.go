@@ -37,6 +37,35 @@ const (
 	TimeEndOfPrevHalfYear   TimeShortcut = "end prev half year"
 	TimeStartOfPrevYear     TimeShortcut = "start prev year"
 	TimeEndOfPrevYear       TimeShortcut = "end prev year"
+	TimeStartOfNextDay      TimeShortcut = "start next day"
+	TimeEndOfNextDay        TimeShortcut = "end next day"
+	TimeStartOfNextWeek     TimeShortcut = "start next week"
+	TimeEndOfNextWeek       TimeShortcut = "end next week"
+	TimeStartOfNextMonth    TimeShortcut = "start next month"
+	TimeEndOfNextMonth      TimeShortcut = "end next month"
+	TimeStartOfNextQuart    TimeShortcut = "start next quart"
+	TimeEndOfNextQuart      TimeShortcut = "end next quart"
+	TimeStartOfNextHalfYear TimeShortcut = "start next half year"
+	TimeEndOfNextHalfYear   TimeShortcut = "end next half year"
+	TimeStartOfNextYear     TimeShortcut = "start next year"
+	TimeEndOfNextYear       TimeShortcut = "end next year"
+	TimeStartOfThisISOYear  TimeShortcut = "start this iso year"
+	TimeEndOfThisISOYear    TimeShortcut = "end this iso year"
+	TimeStartOfPrevISOYear  TimeShortcut = "start prev iso year"
+	TimeEndOfPrevISOYear    TimeShortcut = "end prev iso year"
+	TimeStartOfNextISOYear  TimeShortcut = "start next iso year"
+	TimeEndOfNextISOYear    TimeShortcut = "end next iso year"
+
+	TimeStartOfPrevBusinessDay      TimeShortcut = "start prev business day"
+	TimeEndOfPrevBusinessDay        TimeShortcut = "end prev business day"
+	TimeStartOfNextBusinessDay      TimeShortcut = "start next business day"
+	TimeEndOfNextBusinessDay        TimeShortcut = "end next business day"
+	TimeStartOfThisMonthBusinessDay TimeShortcut = "start this month business day"
+	TimeEndOfThisMonthBusinessDay   TimeShortcut = "end this month business day"
+	TimeStartOfPrevMonthBusinessDay TimeShortcut = "start prev month business day"
+	TimeEndOfPrevMonthBusinessDay   TimeShortcut = "end prev month business day"
+	TimeStartOfNextMonthBusinessDay TimeShortcut = "start next month business day"
+	TimeEndOfNextMonthBusinessDay   TimeShortcut = "end next month business day"
 )
 
 type StartOfWeek int8
@@ -49,10 +78,25 @@ const (
 // TimeFactory is used to make new Time objects by passing the pivot and the shortcut.
 // Method NewTime and RequireTime uses the default time factory which is created
 // by calling NewTimeFactory during the init.
+//
+// The factories of the package implement ConfigurableTimeFactory
+// which extends the interface by more settings and the optional interfaces
+// (see TimeResolver and RuleLister).
 type TimeFactory interface {
 	// Make creates a new Time object by using the rule which is found (or not)
 	// by the given TimeShortcut.
-	// If the rule is not found, ok will be false and t will be a zero-value of Time.
+	// If there is no rule registered with the shortcut, the shortcut is parsed
+	// as a composition of an anchor (start, end or as is), an offset
+	// (this, prev, next, N units ago or in N units) and a unit
+	// (day, week, month, quart, half year or year), e.g. "start 3 months ago"
+	// or "end in 2 quarts". The number of units is at most 10000.
+	// Shortcuts like "start iso week 33 this year" address a week
+	// of the ISO 8601 week-year, shortcuts like "end prev business day"
+	// or "start this month business day" address business days
+	// (see ConfigurableTimeFactory SetWeekend and SetHolidayCalendar).
+	// Registered rules always take priority.
+	// If the rule is not found or it has no result for the pivot (e.g. there is
+	// no business day in a year), ok will be false and t will be a zero-value of Time.
 	Make(pivot time.Time, sc TimeShortcut) (t Time, ok bool)
 
 	// Require creates new Time object by using the rule which is found (or not)
@@ -68,6 +112,7 @@ type TimeFactory interface {
 	// SetStartOfWeek sets the start of the week for the time factory.
 	// It can be Monday or Sunday.
 	// The default value is Monday.
+	// It replaces the week rules, including the ones of a retail calendar.
 	SetStartOfWeek(s StartOfWeek)
 
 	// SetStringer sets your own TimeStringer implementation
@@ -75,15 +120,130 @@ type TimeFactory interface {
 	SetStringer(s TimeStringer)
 }
 
+// TimeMaker is the read-only part of TimeFactory. It's implemented
+// by every time factory of the package including FrozenTimeFactory.
+type TimeMaker interface {
+	// Make creates a new Time object (see TimeFactory Make).
+	Make(pivot time.Time, sc TimeShortcut) (t Time, ok bool)
+
+	// Require creates a new Time object (see TimeFactory Require).
+	Require(pivot time.Time, sc TimeShortcut) Time
+}
+
+// TimeResolver is an optional interface of a time factory
+// which reports why a shortcut can't be resolved.
+// ResolveTime and MustResolveTime use it if the default time factory
+// implements it.
+type TimeResolver interface {
+	// Resolve creates a new Time object like Make does, but if the rule
+	// is not found, it returns an *UnknownShortcutError error which carries
+	// the shortcut and the nearest registered ones. If the rule has no result
+	// for the pivot, the error wraps ErrNoResult.
+	Resolve(pivot time.Time, sc TimeShortcut) (Time, error)
+
+	// MustResolve is like Resolve but panics if the rule is not found.
+	// It simplifies the initialization of variables holding times.
+	MustResolve(pivot time.Time, sc TimeShortcut) Time
+}
+
+// ConfigurableTimeFactory is a time factory with the settings
+// and the capabilities of the time factories of the package.
+// It's returned by NewTimeFactory and the other constructors.
+type ConfigurableTimeFactory interface {
+	TimeFactory
+	TimeResolver
+	RuleLister
+
+	// SetFiscalYear moves the quart, half year and year rules of the time factory
+	// (and the period rules which are based on them) onto the fiscal calendar
+	// which starts at the given month and day.
+	// The default value is CalendarYear.
+	// If the day isn't in the range 1-28, the call is ignored.
+	// It replaces the quart, half year and year rules, including the ones
+	// of a retail calendar.
+	SetFiscalYear(fy FiscalYear)
+
+	// Remove removes the rules registered with the given shortcuts
+	// from the time factory. Unknown shortcuts are ignored.
+	// Note that the shortcuts parsed by the grammar rely on the registered
+	// start and end rules of the units, e.g. removing "start this month"
+	// makes "start 3 months ago" unknown as well.
+	// A removed shortcut isn't parsed by the grammar either, e.g. removing
+	// "start prev month" makes it unknown, until a rule is registered
+	// with it again (see Extend).
+	Remove(shortcuts ...TimeShortcut)
+
+	// Clone returns an independent copy of the time factory with the same rules
+	// and settings, so the copy can be extended or shrunk without affecting
+	// the original one. The copy is of the same kind (safe or nonblocking),
+	// except a frozen factory which is copied to a safe one.
+	Clone() ConfigurableTimeFactory
+
+	// SetWeekend sets the days of the week which aren't business days
+	// for the business day rules of the time factory.
+	// The default value is Saturday and Sunday.
+	// If every day of the week is passed, the call is ignored.
+	SetWeekend(days ...time.Weekday)
+
+	// SetHolidayCalendar sets the calendar of holidays which aren't business days
+	// for the business day rules of the time factory.
+	// The default value is nil which means there are no holidays.
+	SetHolidayCalendar(c HolidayCalendar)
+
+	// SetDayStart sets the clock at which a day starts for the time factory,
+	// e.g. 6*time.Hour means a day lasts from 06:00 to 05:59:59.999999999
+	// of the next calendar day.
+	// Every rule (including the extended ones) is calculated as if the day
+	// started at midnight: the pivot is moved back by the offset on the wall clock
+	// and the result is moved forward, so "prev day", "start this week"
+	// and "end prev month" follow the shifted boundary consistently.
+	// The results which aren't boundaries keep the instant of the pivot:
+	// "as is" is the pivot itself and "as is prev day" is the same moment
+	// of the previous (shifted) day, even across daylight saving time transitions.
+	// The default value is 0 (midnight).
+	// If the offset isn't in the range [0, 24h), the call is ignored.
+	SetDayStart(offset time.Duration)
+
+	// SetPrecision sets the precision of the ends of units, so they survive
+	// a round trip through a storage which keeps less than nanoseconds,
+	// e.g. time.Second makes "end prev day" 23:59:59 instead of
+	// 23:59:59.999999999 which MySQL DATETIME rounds up to the next day.
+	// Every result which is the last moment before a whole second
+	// (including the ones of the extended rules) is moved down to the last moment
+	// of the precision, the other results (e.g. "as is") aren't changed.
+	// The precision must be time.Second, time.Millisecond, time.Microsecond
+	// or time.Nanosecond (the default value), otherwise the call is ignored.
+	SetPrecision(p time.Duration)
+
+	// SetLocation sets the location every pivot is converted into
+	// before any rule runs, so the boundaries (e.g. the start of the month)
+	// are calculated in the location and the results keep it,
+	// whatever the location of the pivot is.
+	// The default value is nil which means pivots are used as is.
+	SetLocation(loc *time.Location)
+}
+
 type unsafeTimeFactory struct {
 	rules map[TimeShortcut]TimeRule
-	s     TimeStringer
+	// removed is the set of the removed shortcuts which aren't parsed
+	// by the grammar (see Remove).
+	removed map[TimeShortcut]struct{}
+	s       TimeStringer
+	bd      businessDays
+	// dayStart is the clock at which a day starts (see SetDayStart).
+	dayStart time.Duration
+	// precision is the precision of the ends of units (see SetPrecision),
+	// zero means nanoseconds.
+	precision time.Duration
+	// loc is the location pivots are converted into (see SetLocation).
+	loc *time.Location
 }
 
-func newUnsafeTimeFactory(rules []TimeRule, s TimeStringer) TimeFactory {
+func newUnsafeTimeFactory(rules []TimeRule, s TimeStringer) *unsafeTimeFactory {
 	f := &unsafeTimeFactory{
 		rules: map[TimeShortcut]TimeRule{},
 		s:     s,
+		bd:    defaultBusinessDays,
 	}
 
 	f.Extend(rules)
@@ -93,15 +253,36 @@ func newUnsafeTimeFactory(rules []TimeRule, s TimeStringer) TimeFactory {
 
 // Make implements the TimeFactory Make method.
 func (f *unsafeTimeFactory) Make(pivot time.Time, sc TimeShortcut) (t Time, ok bool) {
-	r, ok := f.rules[sc]
+	r, ok := f.rule(sc)
 	if !ok {
 		return Time{}, false
 	}
 
-	return Time{
-		t: r.Calculate(pivot),
-		s: f.s,
-	}, true
+	return f.calculate(r, pivot)
+}
+
+// calculate runs the rule with the settings of the factory
+// (the location, the day start, the precision and the stringer).
+// ok is false if the rule has no result, i.e. it returns a zero time.Time.
+func (f *unsafeTimeFactory) calculate(r TimeRule, pivot time.Time) (t Time, ok bool) {
+	if f.loc != nil {
+		pivot = pivot.In(f.loc)
+	}
+
+	if dr, ok := r.(dayStartRule); ok {
+		t.t = dr.calculateDayStart(pivot, f.dayStart)
+	} else {
+		t.t = calculateDayStart(r, pivot, f.dayStart)
+	}
+
+	if t.t.IsZero() {
+		return Time{}, false
+	}
+
+	t.t, t.precision = roundEnd(t.t, f.precision)
+	t.s = f.s
+
+	return t, true
 }
 
 // Require implements the TimeFactory Require method.
@@ -114,6 +295,80 @@ func (f *unsafeTimeFactory) Require(pivot time.Time, sc TimeShortcut) Time {
 	return t
 }
 
+// Resolve implements the TimeResolver Resolve method.
+func (f *unsafeTimeFactory) Resolve(pivot time.Time, sc TimeShortcut) (Time, error) {
+	r, ok := f.rule(sc)
+	if !ok {
+		known := make([]string, 0, len(f.rules))
+		for k := range f.rules {
+			known = append(known, string(k))
+		}
+
+		return Time{}, newUnknownShortcutError(string(sc), known)
+	}
+
+	t, ok := f.calculate(r, pivot)
+	if !ok {
+		return Time{}, noResultError(string(sc), pivot)
+	}
+
+	return t, nil
+}
+
+// MustResolve implements the TimeResolver MustResolve method.
+func (f *unsafeTimeFactory) MustResolve(pivot time.Time, sc TimeShortcut) Time {
+	t, err := f.Resolve(pivot, sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// rule returns the rule which is registered with the shortcut
+// or parsed from it.
+func (f *unsafeTimeFactory) rule(sc TimeShortcut) (TimeRule, bool) {
+	if r, ok := f.rules[sc]; ok {
+		return r, true
+	}
+
+	if _, ok := f.removed[sc]; ok {
+		return nil, false
+	}
+
+	return f.parse(sc)
+}
+
+// parse builds a rule for the shortcut which isn't registered in the factory
+// but matches the shortcut grammar (see parseTimeExpr).
+// The rule relies on the start and the end rules of the unit which are registered.
+func (f *unsafeTimeFactory) parse(sc TimeShortcut) (TimeRule, bool) {
+	if r, ok := parseISOWeek(sc); ok {
+		return r, true
+	}
+
+	if r, ok := f.parseBusinessDay(sc); ok {
+		return r, true
+	}
+
+	e, ok := parseTimeExpr(sc)
+	if !ok {
+		return nil, false
+	}
+
+	start, ok := f.rules[e.unit.startShortcut()]
+	if !ok {
+		return nil, false
+	}
+
+	end, ok := f.rules[e.unit.endShortcut()]
+	if !ok {
+		return nil, false
+	}
+
+	return &timeRuleExpr{sc: sc, e: e, start: start, end: end}, true
+}
+
 // SetStringer implements the TimeFactory SetStringer method.
 func (f *unsafeTimeFactory) SetStringer(s TimeStringer) {
 	f.s = s
@@ -123,6 +378,7 @@ func (f *unsafeTimeFactory) SetStringer(s TimeStringer) {
 func (f *unsafeTimeFactory) Extend(rules []TimeRule) {
 	for _, r := range rules {
 		f.rules[r.Shortcut()] = r
+		delete(f.removed, r.Shortcut())
 	}
 }
 
@@ -136,6 +392,8 @@ func (f *unsafeTimeFactory) SetStartOfWeek(s StartOfWeek) {
 			&timeRuleEndOfThisWeek{},
 			&timeRuleStartOfPrevWeek{},
 			&timeRuleEndOfPrevWeek{},
+			&timeRuleStartOfNextWeek{},
+			&timeRuleEndOfNextWeek{},
 		}
 	case StartOfWeekSunday:
 		rules = []TimeRule{
@@ -143,12 +401,170 @@ func (f *unsafeTimeFactory) SetStartOfWeek(s StartOfWeek) {
 			&timeRuleEndOfThisWeekS{},
 			&timeRuleStartOfPrevWeekS{},
 			&timeRuleEndOfPrevWeekS{},
+			&timeRuleStartOfNextWeekS{},
+			&timeRuleEndOfNextWeekS{},
 		}
 	}
 
 	f.Extend(rules)
 }
 
+// SetFiscalYear implements the ConfigurableTimeFactory SetFiscalYear method.
+func (f *unsafeTimeFactory) SetFiscalYear(fy FiscalYear) {
+	if !fy.valid() {
+		return
+	}
+
+	f.Extend(fy.rules())
+}
+
+// Remove implements the ConfigurableTimeFactory Remove method.
+func (f *unsafeTimeFactory) Remove(shortcuts ...TimeShortcut) {
+	if f.removed == nil && len(shortcuts) > 0 {
+		f.removed = map[TimeShortcut]struct{}{}
+	}
+
+	for _, sc := range shortcuts {
+		delete(f.rules, sc)
+		f.removed[sc] = struct{}{}
+	}
+}
+
+// Clone implements the ConfigurableTimeFactory Clone method.
+func (f *unsafeTimeFactory) Clone() ConfigurableTimeFactory {
+	return f.clone()
+}
+
+func (f *unsafeTimeFactory) clone() *unsafeTimeFactory {
+	c := *f
+	c.rules = make(map[TimeShortcut]TimeRule, len(f.rules))
+	for sc, r := range f.rules {
+		c.rules[sc] = r
+	}
+
+	c.removed = nil
+	if len(f.removed) > 0 {
+		c.removed = make(map[TimeShortcut]struct{}, len(f.removed))
+		for sc := range f.removed {
+			c.removed[sc] = struct{}{}
+		}
+	}
+
+	return &c
+}
+
+// Rules implements the RuleLister Rules method.
+func (f *unsafeTimeFactory) Rules() []RuleInfo {
+	infos := make([]RuleInfo, 0, len(f.rules))
+	for _, r := range f.rules {
+		infos = append(infos, timeRuleInfo(r))
+	}
+
+	sortRuleInfos(infos)
+
+	return infos
+}
+
+// SetWeekend implements the ConfigurableTimeFactory SetWeekend method.
+func (f *unsafeTimeFactory) SetWeekend(days ...time.Weekday) {
+	var weekend [7]bool
+	for _, d := range days {
+		if d < time.Sunday || d > time.Saturday {
+			return
+		}
+
+		weekend[d] = true
+	}
+
+	if weekend == [7]bool{true, true, true, true, true, true, true} {
+		return
+	}
+
+	f.bd.weekend = weekend
+}
+
+// SetHolidayCalendar implements the ConfigurableTimeFactory SetHolidayCalendar method.
+func (f *unsafeTimeFactory) SetHolidayCalendar(c HolidayCalendar) {
+	f.bd.holidays = c
+}
+
+// SetDayStart implements the ConfigurableTimeFactory SetDayStart method.
+func (f *unsafeTimeFactory) SetDayStart(offset time.Duration) {
+	if offset < 0 || offset >= 24*time.Hour {
+		return
+	}
+
+	f.dayStart = offset
+}
+
+// SetPrecision implements the ConfigurableTimeFactory SetPrecision method.
+func (f *unsafeTimeFactory) SetPrecision(p time.Duration) {
+	switch p {
+	case time.Second, time.Millisecond, time.Microsecond, time.Nanosecond:
+		f.precision = p
+	}
+}
+
+// roundEnd moves the last moment before a whole second (e.g. 23:59:59.999999999)
+// down to the last moment of the precision (e.g. 23:59:59 for time.Second)
+// and returns the precision if t is moved. Other moments are returned as is.
+func roundEnd(t time.Time, p time.Duration) (time.Time, time.Duration) {
+	if p <= time.Nanosecond || t.Nanosecond() != 999999999 {
+		return t, 0
+	}
+
+	return t.Add(time.Nanosecond - p), p
+}
+
+// SetLocation implements the ConfigurableTimeFactory SetLocation method.
+func (f *unsafeTimeFactory) SetLocation(loc *time.Location) {
+	f.loc = loc
+}
+
+// location returns the location pivots are converted into.
+func (f *unsafeTimeFactory) location() *time.Location {
+	return f.loc
+}
+
+// timeLocation returns the location of the time factory if it's a factory
+// of the package (see ConfigurableTimeFactory SetLocation), otherwise nil.
+func timeLocation(tf TimeFactory) *time.Location {
+	if l, ok := tf.(interface{ location() *time.Location }); ok {
+		return l.location()
+	}
+
+	return nil
+}
+
+// dayStartRule is implemented by the rules which results aren't boundaries
+// of units (e.g. "as is"), so they keep the instant of the pivot
+// instead of being moved by the day start (see SetDayStart).
+type dayStartRule interface {
+	calculateDayStart(pivot time.Time, d time.Duration) time.Time
+}
+
+// calculateDayStart calculates a boundary as if the day started at midnight:
+// the pivot is moved back by d on the wall clock and the result is moved forward.
+func calculateDayStart(r TimeRule, pivot time.Time, d time.Duration) time.Time {
+	return shiftClock(r.Calculate(shiftClock(pivot, -d)), d)
+}
+
+// shiftClock moves t by d on the wall clock, so the result keeps
+// the clock even if there is a daylight saving time transition between them.
+// A zero-value isn't moved.
+func shiftClock(t time.Time, d time.Duration) time.Time {
+	if d == 0 || t.IsZero() {
+		return t
+	}
+
+	// d is split into the components of the clock,
+	// so the sums don't overflow int on 32-bit platforms.
+	h, m, s := d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+int(h), t.Minute()+int(m),
+		t.Second()+int(s), t.Nanosecond()+int(d%time.Second), t.Location())
+}
+
 var defaultRules = []TimeRule{
 	&timeRuleAsIs{},
 	thisDayStartRule,
@@ -175,14 +591,32 @@ var defaultRules = []TimeRule{
 	&timeRuleEndOfThisYear{},
 	&timeRuleStartOfPrevYear{},
 	&timeRuleEndOfPrevYear{},
+	&timeRuleStartOfNextDay{},
+	&timeRuleEndOfNextDay{},
+	&timeRuleStartOfNextWeek{},
+	&timeRuleEndOfNextWeek{},
+	&timeRuleStartOfNextMonth{},
+	&timeRuleEndOfNextMonth{},
+	&timeRuleStartOfNextQuart{},
+	&timeRuleEndOfNextQuart{},
+	&timeRuleStartOfNextHalfYear{},
+	&timeRuleEndOfNextHalfYear{},
+	&timeRuleStartOfNextYear{},
+	&timeRuleEndOfNextYear{},
+	&timeRuleISOYear{e: timeExpr{anchor: anchorStart, offset: 0, unit: unitISOYear}},
+	&timeRuleISOYear{e: timeExpr{anchor: anchorEnd, offset: 0, unit: unitISOYear}},
+	&timeRuleISOYear{e: timeExpr{anchor: anchorStart, offset: -1, unit: unitISOYear}},
+	&timeRuleISOYear{e: timeExpr{anchor: anchorEnd, offset: -1, unit: unitISOYear}},
+	&timeRuleISOYear{e: timeExpr{anchor: anchorStart, offset: 1, unit: unitISOYear}},
+	&timeRuleISOYear{e: timeExpr{anchor: anchorEnd, offset: 1, unit: unitISOYear}},
 }
 
-var defaultTimeFactory = NewTimeFactory()
+var defaultTimeFactory TimeFactory = defaultCalendar.Times()
 
 // safeTimeFactory is a decorator which wraps a time factory for concurrent use
 // by multiple goroutines.
 type safeTimeFactory struct {
-	f  TimeFactory
+	f  *unsafeTimeFactory
 	rw sync.RWMutex
 }
 
@@ -202,6 +636,24 @@ func (f *safeTimeFactory) Require(pivot time.Time, sc TimeShortcut) Time {
 	return f.f.Require(pivot, sc)
 }
 
+// Resolve implements the TimeResolver Resolve method.
+func (f *safeTimeFactory) Resolve(pivot time.Time, sc TimeShortcut) (Time, error) {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+
+	return f.f.Resolve(pivot, sc)
+}
+
+// MustResolve implements the TimeResolver MustResolve method.
+func (f *safeTimeFactory) MustResolve(pivot time.Time, sc TimeShortcut) Time {
+	t, err := f.Resolve(pivot, sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
 // SetStringer implements the TimeFactory SetStringer method.
 func (f *safeTimeFactory) SetStringer(s TimeStringer) {
 	f.rw.Lock()
@@ -226,15 +678,106 @@ func (f *safeTimeFactory) SetStartOfWeek(s StartOfWeek) {
 	f.f.SetStartOfWeek(s)
 }
 
-func newSafeTimeFactory(f TimeFactory) TimeFactory {
+// SetFiscalYear implements the ConfigurableTimeFactory SetFiscalYear method.
+func (f *safeTimeFactory) SetFiscalYear(fy FiscalYear) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.SetFiscalYear(fy)
+}
+
+// Remove implements the ConfigurableTimeFactory Remove method.
+func (f *safeTimeFactory) Remove(shortcuts ...TimeShortcut) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.Remove(shortcuts...)
+}
+
+// Clone implements the ConfigurableTimeFactory Clone method.
+func (f *safeTimeFactory) Clone() ConfigurableTimeFactory {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+
+	return newSafeTimeFactory(f.f.clone())
+}
+
+// SetLocation implements the ConfigurableTimeFactory SetLocation method.
+func (f *safeTimeFactory) SetLocation(loc *time.Location) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.SetLocation(loc)
+}
+
+// Rules implements the RuleLister Rules method.
+func (f *safeTimeFactory) Rules() []RuleInfo {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+
+	return f.f.Rules()
+}
+
+// SetWeekend implements the ConfigurableTimeFactory SetWeekend method.
+func (f *safeTimeFactory) SetWeekend(days ...time.Weekday) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.SetWeekend(days...)
+}
+
+// SetHolidayCalendar implements the ConfigurableTimeFactory SetHolidayCalendar method.
+func (f *safeTimeFactory) SetHolidayCalendar(c HolidayCalendar) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.SetHolidayCalendar(c)
+}
+
+// SetDayStart implements the ConfigurableTimeFactory SetDayStart method.
+func (f *safeTimeFactory) SetDayStart(offset time.Duration) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.SetDayStart(offset)
+}
+
+// SetPrecision implements the ConfigurableTimeFactory SetPrecision method.
+func (f *safeTimeFactory) SetPrecision(p time.Duration) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	f.f.SetPrecision(p)
+}
+
+// location returns the location pivots are converted into.
+func (f *safeTimeFactory) location() *time.Location {
+	f.rw.RLock()
+	defer f.rw.RUnlock()
+
+	return f.f.loc
+}
+
+// apply applies the options to the wrapped factory.
+func (f *safeTimeFactory) apply(opts []TimeOption) {
+	f.rw.Lock()
+	defer f.rw.Unlock()
+
+	for _, opt := range opts {
+		opt(f.f)
+	}
+}
+
+func newSafeTimeFactory(f *unsafeTimeFactory) *safeTimeFactory {
 	return &safeTimeFactory{f: f}
 }
 
 // NewTimeFactory creates a time factory which is ready to extend and
 // safe for concurrent use by multiple goroutines.
-func NewTimeFactory() TimeFactory {
+// The options are applied to the factory in the given order.
+func NewTimeFactory(opts ...TimeOption) ConfigurableTimeFactory {
 	return newSafeTimeFactory(
-		NewNonblockingTimeFactory())
+		newUnsafeTimeFactoryWith(opts))
 }
 
 // NewNonblockingTimeFactory creates an unsafe time factory which is ready to extend.
@@ -245,8 +788,20 @@ func NewTimeFactory() TimeFactory {
 //
 // It might be useful when your application is under high load and your time factory
 // doesn't use Extend method at all or use it once during the init.
-func NewNonblockingTimeFactory() TimeFactory {
-	return newUnsafeTimeFactory(defaultRules, &defaultTimeStringer{})
+// The options are applied to the factory in the given order.
+func NewNonblockingTimeFactory(opts ...TimeOption) ConfigurableTimeFactory {
+	return newUnsafeTimeFactoryWith(opts)
+}
+
+// newUnsafeTimeFactoryWith creates an unsafe time factory with the default rules
+// and the options applied in the given order.
+func newUnsafeTimeFactoryWith(opts []TimeOption) *unsafeTimeFactory {
+	f := newUnsafeTimeFactory(defaultRules, &defaultTimeStringer{})
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
 }
 
 // SetDefaultTimeFactory sets your own time factory as default.
@@ -254,12 +809,18 @@ func NewNonblockingTimeFactory() TimeFactory {
 // After that you can use NewTime or RequireTime functions
 // without concreting a factory like that rdate.NewTime(...)
 // or rdate.RequireTime(...))
+//
+// The default period factory keeps calculating periods by its own
+// time factory, so the settings of the new one (e.g. the location)
+// don't affect the default periods, use SetDefaultCalendar to replace both of them.
 func SetDefaultTimeFactory(f TimeFactory) {
 	defaultTimeFactory = f
 }
 
 // SetDefaultStartOfWeek sets the start of the week for the default time factory.
 // It can be Monday or Sunday.
+// The default period factory shares the time factory (see Calendar),
+// so its week periods follow the change too.
 func SetDefaultStartOfWeek(s StartOfWeek) {
 	defaultTimeFactory.SetStartOfWeek(s)
 }
@@ -267,6 +828,10 @@ func SetDefaultStartOfWeek(s StartOfWeek) {
 type Time struct {
 	t time.Time
 	s TimeStringer
+	// precision is set if t is the end of a unit which is moved down
+	// to the precision of the factory (see ConfigurableTimeFactory SetPrecision)
+	// or the first moment after such an end (see exclusiveEnd).
+	precision time.Duration
 }
 
 // NewTime calls Make method of the default time factory.
@@ -279,6 +844,39 @@ func RequireTime(pivot time.Time, sc TimeShortcut) Time {
 	return defaultTimeFactory.Require(pivot, sc)
 }
 
+// ResolveTime calls Resolve method of the default time factory.
+// If the factory doesn't implement TimeResolver, its Make method is called
+// and the error of an unknown shortcut carries the nearest of its rules
+// only if it implements RuleLister.
+func ResolveTime(pivot time.Time, sc TimeShortcut) (Time, error) {
+	return resolveTime(defaultTimeFactory, pivot, sc)
+}
+
+// resolveTime calls Resolve method of the time factory if it implements
+// TimeResolver, otherwise it reports the failure of Make as an unknown shortcut.
+func resolveTime(f TimeFactory, pivot time.Time, sc TimeShortcut) (Time, error) {
+	if r, ok := f.(TimeResolver); ok {
+		return r.Resolve(pivot, sc)
+	}
+
+	t, ok := f.Make(pivot, sc)
+	if !ok {
+		return Time{}, newUnknownShortcutError(string(sc), listShortcuts(f))
+	}
+
+	return t, nil
+}
+
+// MustResolveTime is like ResolveTime but panics if the rule is not found.
+func MustResolveTime(pivot time.Time, sc TimeShortcut) Time {
+	t, err := ResolveTime(pivot, sc)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
 // Time is a getter of the internal time.Time value of the type.
 func (t Time) Time() time.Time {
 	return t.t
@@ -292,6 +890,12 @@ func (t Time) String() string {
 	return t.s.String(t.t)
 }
 
+// ISOWeek returns the ISO 8601 week-year and the week number
+// in which the time occurs (see time.Time ISOWeek method).
+func (t Time) ISOWeek() (year, week int) {
+	return t.t.ISOWeek()
+}
+
 // IsZero reports if the value is a zero-value of the type
 func (t Time) IsZero() bool {
 	return t.s == nil && t.t.IsZero()
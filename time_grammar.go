@@ -0,0 +1,343 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unit is a calendar unit which a shortcut of the grammar is built on.
+type unit int8
+
+const (
+	unitDay unit = iota + 1
+	unitWeek
+	unitMonth
+	unitQuart
+	unitHalfYear
+	unitYear
+	unitISOYear
+)
+
+var unitNames = map[unit]string{
+	unitDay:      "day",
+	unitWeek:     "week",
+	unitMonth:    "month",
+	unitQuart:    "quart",
+	unitHalfYear: "half year",
+	unitYear:     "year",
+	unitISOYear:  "iso year",
+}
+
+// startShortcut returns the shortcut of the rule which calculates
+// the start of the unit the pivot belongs to.
+func (u unit) startShortcut() TimeShortcut {
+	return TimeShortcut("start this " + unitNames[u])
+}
+
+// endShortcut returns the shortcut of the rule which calculates
+// the end of the unit the pivot belongs to.
+func (u unit) endShortcut() TimeShortcut {
+	return TimeShortcut("end this " + unitNames[u])
+}
+
+type anchor int8
+
+const (
+	anchorStart anchor = iota + 1
+	anchorEnd
+	anchorAsIs
+)
+
+// timeExpr is a parsed time shortcut.
+// The offset is a number of units relative to the unit the pivot belongs to:
+// 0 is this, -1 is prev, 1 is next and so on.
+type timeExpr struct {
+	anchor anchor
+	offset int
+	unit   unit
+}
+
+var anchorNames = map[anchor]string{
+	anchorStart: "start",
+	anchorEnd:   "end",
+	anchorAsIs:  "as is",
+}
+
+// shortcut formats the expression back to a time shortcut.
+// Offsets -1, 0 and 1 are formatted as prev, this and next,
+// so the rules registered with such shortcuts are used.
+func (e timeExpr) shortcut() TimeShortcut {
+	return TimeShortcut(anchorNames[e.anchor] + " " + formatOffset(e.offset, e.unit))
+}
+
+// maxUnits is the max number of units in a shortcut of the grammar,
+// so a shortcut which comes from a config can't make a factory step
+// through millions of units. It's more than 27 years of days.
+const maxUnits = 10000
+
+func formatOffset(offset int, u unit) string {
+	switch offset {
+	case -1:
+		return "prev " + unitNames[u]
+	case 0:
+		return "this " + unitNames[u]
+	case 1:
+		return "next " + unitNames[u]
+	}
+
+	if offset < 0 {
+		return formatNumberOfUnits(-offset, u) + " ago"
+	}
+
+	return "in " + formatNumberOfUnits(offset, u)
+}
+
+func formatNumberOfUnits(n int, u unit) string {
+	if n == 1 {
+		return "1 " + unitNames[u]
+	}
+
+	return strconv.Itoa(n) + " " + unitNames[u] + "s"
+}
+
+// parseTimeExpr parses a time shortcut of the following grammar:
+//
+//	shortcut = anchor offset
+//	anchor   = "start" | "end" | "as is"
+//	offset   = ("this" | "prev" | "next") unit
+//	         | number units "ago"
+//	         | "in" number units
+//	unit     = "day" | "week" | "month" | "quart" | "half year" | "year" | "iso year"
+//
+// Plural forms of units are allowed as well ("3 months ago", "in 2 quarts").
+// The number is at most maxUnits.
+func parseTimeExpr(sc TimeShortcut) (e timeExpr, ok bool) {
+	words := strings.Fields(string(sc))
+
+	e.anchor, words, ok = parseAnchor(words)
+	if !ok {
+		return timeExpr{}, false
+	}
+
+	e.offset, e.unit, ok = parseOffset(words)
+	if !ok {
+		return timeExpr{}, false
+	}
+
+	return e, true
+}
+
+func parseAnchor(words []string) (a anchor, rest []string, ok bool) {
+	switch {
+	case len(words) > 0 && words[0] == "start":
+		return anchorStart, words[1:], true
+	case len(words) > 0 && words[0] == "end":
+		return anchorEnd, words[1:], true
+	case len(words) > 1 && words[0] == "as" && words[1] == "is":
+		return anchorAsIs, words[2:], true
+	}
+
+	return 0, nil, false
+}
+
+// parseOffset parses the offset part of the grammar.
+// It's shared with the period grammar.
+func parseOffset(words []string) (offset int, u unit, ok bool) {
+	if len(words) < 2 {
+		return 0, 0, false
+	}
+
+	switch words[0] {
+	case "this", "prev", "next":
+		u, ok = parseUnit(words[1:], false)
+		if !ok {
+			return 0, 0, false
+		}
+
+		switch words[0] {
+		case "prev":
+			offset = -1
+		case "next":
+			offset = 1
+		}
+
+		return offset, u, true
+	case "in":
+		n, u, ok := parseNumberOfUnits(words[1:])
+		if !ok {
+			return 0, 0, false
+		}
+
+		return n, u, true
+	}
+
+	if words[len(words)-1] != "ago" {
+		return 0, 0, false
+	}
+
+	n, u, ok := parseNumberOfUnits(words[:len(words)-1])
+	if !ok {
+		return 0, 0, false
+	}
+
+	return -n, u, true
+}
+
+func parseNumberOfUnits(words []string) (n int, u unit, ok bool) {
+	if len(words) < 2 {
+		return 0, 0, false
+	}
+
+	n, err := strconv.Atoi(words[0])
+	if err != nil || n < 0 || n > maxUnits || words[0][0] == '+' {
+		return 0, 0, false
+	}
+
+	u, ok = parseUnit(words[1:], n != 1)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return n, u, true
+}
+
+// parseUnit parses a unit. The plural form is accepted only if plural is true,
+// the singular one is always accepted.
+func parseUnit(words []string, plural bool) (u unit, ok bool) {
+	name := strings.Join(words, " ")
+
+	for u, s := range unitNames {
+		if name == s || (plural && name == s+"s") {
+			return u, true
+		}
+	}
+
+	return 0, false
+}
+
+// timeRuleExpr calculates a parsed time shortcut by using the rules
+// of the start and the end of the unit.
+// Thus it follows all the settings of the time factory (e.g. StartOfWeek).
+type timeRuleExpr struct {
+	sc    TimeShortcut
+	e     timeExpr
+	start TimeRule
+	end   TimeRule
+}
+
+func (r *timeRuleExpr) Calculate(pivot time.Time) time.Time {
+	ts := shiftUnits(pivot, r.e.offset, r.start, r.end)
+
+	switch r.e.anchor {
+	case anchorStart:
+		return r.start.Calculate(ts)
+	case anchorEnd:
+		return r.end.Calculate(ts)
+	}
+
+	if r.e.offset == 0 {
+		return pivot
+	}
+
+	return keepOffset(pivot, r.start.Calculate(pivot),
+		r.start.Calculate(ts), r.end.Calculate(ts))
+}
+
+// calculateDayStart calculates the boundaries of the units on the pivot moved
+// by the day start (see calculateDayStart function), but the result
+// of "as is" keeps the clock of the pivot, not the moved one.
+func (r *timeRuleExpr) calculateDayStart(pivot time.Time, d time.Duration) time.Time {
+	if r.e.anchor != anchorAsIs {
+		return calculateDayStart(r, pivot, d)
+	}
+
+	if r.e.offset == 0 {
+		return pivot
+	}
+
+	shifted := shiftClock(pivot, -d)
+	ts := shiftUnits(shifted, r.e.offset, r.start, r.end)
+
+	return keepOffset(pivot, shiftClock(r.start.Calculate(shifted), d),
+		shiftClock(r.start.Calculate(ts), d), shiftClock(r.end.Calculate(ts), d))
+}
+
+func (r *timeRuleExpr) Shortcut() TimeShortcut { return r.sc }
+
+// shiftUnits moves the pivot to the unit which is offset units away
+// from the unit the pivot belongs to.
+// The result is any moment of that unit.
+// Days, weeks and months of the default rules are moved by the calendar,
+// other units are stepped through one by one (see maxUnits).
+func shiftUnits(pivot time.Time, offset int, start, end TimeRule) time.Time {
+	if ts, ok := shiftCalendarUnits(pivot, offset, start); ok {
+		return ts
+	}
+
+	ts := pivot
+	for ; offset < 0; offset++ {
+		ts = start.Calculate(ts).Add(-time.Nanosecond)
+	}
+	for ; offset > 0; offset-- {
+		ts = end.Calculate(ts).Add(time.Second)
+	}
+
+	return ts
+}
+
+// shiftCalendarUnits moves the pivot by days, weeks or months to the noon
+// of a date of the unit if the start rule of the unit is a default one.
+// ok is false for other rules and if the date is skipped in the location
+// (e.g. by a change of the time zone), those are stepped through.
+func shiftCalendarUnits(pivot time.Time, offset int, start TimeRule) (ts time.Time, ok bool) {
+	y, m, d := pivot.Date()
+
+	switch start.(type) {
+	case *timeRuleStartOfThisDay:
+		d += offset
+	case *timeRuleStartOfThisWeek, *timeRuleStartOfThisWeekS:
+		d += 7 * offset
+	case *timeRuleStartOfThisMonth:
+		m, d = m+time.Month(offset), 15
+	default:
+		return time.Time{}, false
+	}
+
+	y, m, d = time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Date()
+
+	ts = time.Date(y, m, d, 12, 0, 0, 0, pivot.Location())
+	if ty, tm, td := ts.Date(); ty != y || tm != m || td != d {
+		return time.Time{}, false
+	}
+
+	return ts, true
+}
+
+// keepOffset returns the moment of the unit [from, to] which has
+// the same number of days and the same clock from the start of the unit
+// as the pivot has from the start of its own unit (base).
+// The result never goes beyond the end of the unit.
+func keepOffset(pivot, base, from, to time.Time) time.Time {
+	days := civilDays(base, pivot)
+
+	t := time.Date(from.Year(), from.Month(), from.Day()+days,
+		pivot.Hour(), pivot.Minute(), pivot.Second(), pivot.Nanosecond(), pivot.Location())
+	if t.After(to) {
+		return to
+	}
+
+	return t
+}
+
+// civilDays returns the number of calendar days between the dates of a and b.
+func civilDays(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(db.Sub(da).Hours() / 24)
+}
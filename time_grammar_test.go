@@ -0,0 +1,247 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestTimeFactory_grammar(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pivot    time.Time
+		sc       rdate.TimeShortcut
+		expected time.Time
+	}{
+		{
+			name:     "StartOf3MonthsAgo",
+			pivot:    time.Date(2020, 2, 29, 0, 2, 1, 6, time.UTC),
+			sc:       "start 3 months ago",
+			expected: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOf2QuartsAgo",
+			pivot:    time.Date(2020, 2, 29, 0, 2, 1, 6, time.UTC),
+			sc:       "end 2 quarts ago",
+			expected: time.Date(2019, 9, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOf1WeekAgo",
+			pivot:    time.Date(2020, 8, 9, 0, 2, 1, 6, time.UTC),
+			sc:       "start 1 week ago",
+			expected: time.Date(2020, 7, 27, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOf10DaysAgo",
+			pivot:    time.Date(2020, 3, 5, 0, 2, 1, 6, time.UTC),
+			sc:       "end 10 days ago",
+			expected: time.Date(2020, 2, 24, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOf0YearsAgo",
+			pivot:    time.Date(2020, 3, 5, 0, 2, 1, 6, time.UTC),
+			sc:       "start 0 years ago",
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "StartIn2HalfYears",
+			pivot:    time.Date(2020, 8, 5, 0, 2, 1, 6, time.UTC),
+			sc:       "start in 2 half years",
+			expected: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndIn1Month",
+			pivot:    time.Date(2020, 1, 31, 0, 2, 1, 6, time.UTC),
+			sc:       "end in 1 month",
+			expected: time.Date(2020, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartNextWeek",
+			pivot:    time.Date(2020, 8, 9, 0, 2, 1, 6, time.UTC),
+			sc:       "start next week",
+			expected: time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndNextYear",
+			pivot:    time.Date(2020, 8, 9, 0, 2, 1, 6, time.UTC),
+			sc:       "end next year",
+			expected: time.Date(2021, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "AsIsPrevMonth",
+			pivot:    time.Date(2020, 8, 11, 10, 2, 1, 6, time.UTC),
+			sc:       "as is prev month",
+			expected: time.Date(2020, 7, 11, 10, 2, 1, 6, time.UTC),
+		},
+		{
+			name:     "AsIsPrevMonth(clamped)",
+			pivot:    time.Date(2020, 3, 31, 10, 2, 1, 6, time.UTC),
+			sc:       "as is prev month",
+			expected: time.Date(2020, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "AsIs2WeeksAgo",
+			pivot:    time.Date(2020, 8, 12, 10, 2, 1, 6, time.UTC),
+			sc:       "as is 2 weeks ago",
+			expected: time.Date(2020, 7, 29, 10, 2, 1, 6, time.UTC),
+		},
+		{
+			name:     "AsIsThisYear",
+			pivot:    time.Date(2020, 8, 12, 10, 2, 1, 6, time.UTC),
+			sc:       "as is this year",
+			expected: time.Date(2020, 8, 12, 10, 2, 1, 6, time.UTC),
+		},
+	}
+
+	f := rdate.NewTimeFactory()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := f.Make(tc.pivot, tc.sc)
+			if !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			timeEqual(t, actual, tc.expected)
+		})
+	}
+}
+
+func TestTimeFactory_grammarMatchesRules(t *testing.T) {
+	testCases := []struct {
+		sc       rdate.TimeShortcut
+		expected rdate.TimeShortcut
+	}{
+		{sc: "start 1 day ago", expected: rdate.TimeStartOfPrevDay},
+		{sc: "end 1 day ago", expected: rdate.TimeEndOfPrevDay},
+		{sc: "start 1 week ago", expected: rdate.TimeStartOfPrevWeek},
+		{sc: "end 1 week ago", expected: rdate.TimeEndOfPrevWeek},
+		{sc: "start 1 month ago", expected: rdate.TimeStartOfPrevMonth},
+		{sc: "end 1 month ago", expected: rdate.TimeEndOfPrevMonth},
+		{sc: "start 1 quart ago", expected: rdate.TimeStartOfPrevQuart},
+		{sc: "end 1 quart ago", expected: rdate.TimeEndOfPrevQuart},
+		{sc: "start 1 half year ago", expected: rdate.TimeStartOfPrevHalfYear},
+		{sc: "end 1 half year ago", expected: rdate.TimeEndOfPrevHalfYear},
+		{sc: "start 1 year ago", expected: rdate.TimeStartOfPrevYear},
+		{sc: "end 1 year ago", expected: rdate.TimeEndOfPrevYear},
+		{sc: "start in 1 day", expected: rdate.TimeStartOfNextDay},
+		{sc: "end in 1 day", expected: rdate.TimeEndOfNextDay},
+		{sc: "start in 1 week", expected: rdate.TimeStartOfNextWeek},
+		{sc: "end in 1 week", expected: rdate.TimeEndOfNextWeek},
+		{sc: "start in 1 month", expected: rdate.TimeStartOfNextMonth},
+		{sc: "end in 1 month", expected: rdate.TimeEndOfNextMonth},
+		{sc: "start in 1 quart", expected: rdate.TimeStartOfNextQuart},
+		{sc: "end in 1 quart", expected: rdate.TimeEndOfNextQuart},
+		{sc: "start in 1 half year", expected: rdate.TimeStartOfNextHalfYear},
+		{sc: "end in 1 half year", expected: rdate.TimeEndOfNextHalfYear},
+		{sc: "start in 1 year", expected: rdate.TimeStartOfNextYear},
+		{sc: "end in 1 year", expected: rdate.TimeEndOfNextYear},
+		{sc: "start 1 iso year ago", expected: rdate.TimeStartOfPrevISOYear},
+		{sc: "end 1 iso year ago", expected: rdate.TimeEndOfPrevISOYear},
+		{sc: "start in 1 iso year", expected: rdate.TimeStartOfNextISOYear},
+		{sc: "end in 1 iso year", expected: rdate.TimeEndOfNextISOYear},
+		{sc: "start 0 months ago", expected: rdate.TimeStartOfThisMonth},
+		{sc: "end 0 months ago", expected: rdate.TimeEndOfThisMonth},
+	}
+
+	f := rdate.NewTimeFactory()
+	f.SetStartOfWeek(rdate.StartOfWeekSunday)
+
+	pivot := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 800; i++ {
+		ts := pivot.AddDate(0, 0, i)
+
+		for _, tc := range testCases {
+			actual, ok := f.Make(ts, tc.sc)
+			if !ok {
+				t.Fatalf("'%s' expected ok but it isn't", tc.sc)
+			}
+
+			timeEqual(t, actual, f.Require(ts, tc.expected).Time())
+		}
+	}
+}
+
+// steppedRule delegates to a rule of the default factory,
+// so the grammar can't recognize it and steps through the units.
+type steppedRule struct {
+	sc rdate.TimeShortcut
+}
+
+func (r *steppedRule) Calculate(pivot time.Time) time.Time {
+	return rdate.NewNonblockingTimeFactory().Require(pivot, r.sc).Time()
+}
+
+func (r *steppedRule) Shortcut() rdate.TimeShortcut { return r.sc }
+
+func TestTimeFactory_grammarLargeOffsets(t *testing.T) {
+	loc := loadLocation(t, "America/New_York")
+
+	stepped := rdate.NewTimeFactory(rdate.WithTimeRules(
+		&steppedRule{sc: rdate.TimeStartOfThisDay}, &steppedRule{sc: rdate.TimeEndOfThisDay},
+		&steppedRule{sc: rdate.TimeStartOfThisWeek}, &steppedRule{sc: rdate.TimeEndOfThisWeek},
+		&steppedRule{sc: rdate.TimeStartOfThisMonth}, &steppedRule{sc: rdate.TimeEndOfThisMonth},
+	))
+	f := rdate.NewTimeFactory()
+
+	shortcuts := []rdate.TimeShortcut{
+		"start 10000 days ago", "end in 10000 days", "as is 9999 days ago",
+		"start 1430 weeks ago", "end in 1000 weeks",
+		"start 10000 months ago", "end in 10000 months", "as is 1000 months ago",
+	}
+
+	for _, pivot := range []time.Time{
+		time.Date(2020, 3, 31, 10, 2, 1, 6, loc),
+		time.Date(2021, 11, 7, 1, 30, 0, 0, loc),
+	} {
+		for _, sc := range shortcuts {
+			expected, ok := stepped.Make(pivot, sc)
+			if !ok {
+				t.Fatalf("%s: expected ok but it isn't", sc)
+			}
+
+			timeEqual(t, f.Require(pivot, sc), expected.Time())
+		}
+	}
+
+	timeEqual(t, f.Require(time.Date(2020, 3, 31, 10, 2, 1, 6, time.UTC), "start 10000 days ago"),
+		time.Date(1992, 11, 13, 0, 0, 0, 0, time.UTC))
+}
+
+func TestTimeFactory_grammarFails(t *testing.T) {
+	testCases := []rdate.TimeShortcut{
+		"",
+		"start",
+		"start this",
+		"start this decade",
+		"start 3 month",
+		"start -3 months ago",
+		"start +3 months ago",
+		"start three months ago",
+		"start prev 3 months",
+		"in 3 months",
+		"beginning prev month",
+		"as prev month",
+		"start 10001 days ago",
+		"end in 1000000000 years",
+	}
+
+	f := rdate.NewTimeFactory()
+
+	for _, sc := range testCases {
+		t.Run(string(sc), func(t *testing.T) {
+			actual, ok := f.Make(time.Now(), sc)
+			if ok {
+				t.Errorf("expected ok = false but it's true")
+			}
+			if !actual.IsZero() {
+				t.Errorf("expected a zero-value but it isn't")
+			}
+		})
+	}
+}
@@ -16,6 +16,66 @@ var (
 	thisDayEndRule   = &timeRuleEndOfThisDay{}
 )
 
+// startOfDay returns the first moment of the date in the location.
+// The date is normalized the same way time.Date does it, e.g. day 0
+// is the last day of the previous month.
+//
+// Midnight doesn't exist in the zones which switch to daylight saving time
+// at midnight (e.g. America/Sao_Paulo till 2019) and occurs twice
+// in the zones which switch back at midnight, so time.Date at midnight
+// might return a moment of the previous day or the second occurrence
+// of the midnight. In these cases the first moment of the date
+// is searched instead.
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	year, month, day = time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Date()
+
+	t := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if !beforeDate(t, year, month, day) &&
+		beforeDate(t.Add(-time.Nanosecond), year, month, day) {
+		return t
+	}
+
+	// The local date doesn't move back by more than a day in a transition,
+	// so the first moment of the date is between lo and hi.
+	hi := time.Date(year, month, day, 12, 0, 0, 0, loc)
+	lo := hi.Add(-48 * time.Hour)
+	for hi.Sub(lo) > time.Nanosecond {
+		mid := lo.Add(hi.Sub(lo) / 2)
+		if beforeDate(mid, year, month, day) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return hi
+}
+
+// endOfDay returns the last moment of the date in the location,
+// i.e. the moment right before the start of the next day (see startOfDay).
+func endOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return startOfDay(year, month, day+1, loc).Add(-time.Nanosecond)
+}
+
+// beforeDate reports whether the local date of t is before the date.
+func beforeDate(t time.Time, year int, month time.Month, day int) bool {
+	y, m, d := t.Date()
+	if y != year {
+		return y < year
+	}
+	if m != month {
+		return m < month
+	}
+
+	return d < day
+}
+
+// daysSinceMonday returns the number of days passed from Monday
+// of the week t belongs to.
+func daysSinceMonday(t time.Time) int {
+	return (int(t.Weekday()) + 6) % 7
+}
+
 type timeRuleAsIs struct{}
 
 func (r *timeRuleAsIs) Calculate(pivot time.Time) time.Time {
@@ -24,11 +84,15 @@ func (r *timeRuleAsIs) Calculate(pivot time.Time) time.Time {
 
 func (r *timeRuleAsIs) Shortcut() TimeShortcut { return TimeAsIs }
 
+func (r *timeRuleAsIs) calculateDayStart(pivot time.Time, d time.Duration) time.Time {
+	return pivot
+}
+
 type timeRuleStartOfThisDay struct{}
 
 func (r *timeRuleStartOfThisDay) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.Year(), pivot.Month(), pivot.Day(), 0, 0, 0, 0,
-		pivot.Location())
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d, pivot.Location())
 }
 
 func (r *timeRuleStartOfThisDay) Shortcut() TimeShortcut { return TimeStartOfThisDay }
@@ -36,8 +100,8 @@ func (r *timeRuleStartOfThisDay) Shortcut() TimeShortcut { return TimeStartOfThi
 type timeRuleEndOfThisDay struct{}
 
 func (r *timeRuleEndOfThisDay) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.Year(), pivot.Month(), pivot.Day(), 23, 59, 59, 999999999,
-		pivot.Location())
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d, pivot.Location())
 }
 
 func (r *timeRuleEndOfThisDay) Shortcut() TimeShortcut { return TimeEndOfThisDay }
@@ -45,7 +109,8 @@ func (r *timeRuleEndOfThisDay) Shortcut() TimeShortcut { return TimeEndOfThisDay
 type timeRuleStartOfPrevDay struct{}
 
 func (r *timeRuleStartOfPrevDay) Calculate(pivot time.Time) time.Time {
-	return thisDayStartRule.Calculate(pivot).AddDate(0, 0, -1)
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d-1, pivot.Location())
 }
 
 func (r *timeRuleStartOfPrevDay) Shortcut() TimeShortcut { return TimeStartOfPrevDay }
@@ -53,7 +118,8 @@ func (r *timeRuleStartOfPrevDay) Shortcut() TimeShortcut { return TimeStartOfPre
 type timeRuleEndOfPrevDay struct{}
 
 func (r *timeRuleEndOfPrevDay) Calculate(pivot time.Time) time.Time {
-	return thisDayEndRule.Calculate(pivot).AddDate(0, 0, -1)
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d-1, pivot.Location())
 }
 
 func (r *timeRuleEndOfPrevDay) Shortcut() TimeShortcut { return TimeEndOfPrevDay }
@@ -61,11 +127,8 @@ func (r *timeRuleEndOfPrevDay) Shortcut() TimeShortcut { return TimeEndOfPrevDay
 type timeRuleStartOfThisWeek struct{}
 
 func (r *timeRuleStartOfThisWeek) Calculate(pivot time.Time) time.Time {
-	ts := thisDayStartRule.Calculate(pivot)
-	if ts.Weekday() == time.Sunday {
-		return ts.AddDate(0, 0, -6)
-	}
-	return ts.AddDate(0, 0, -(int(ts.Weekday()) - 1))
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d-daysSinceMonday(pivot), pivot.Location())
 }
 
 func (r *timeRuleStartOfThisWeek) Shortcut() TimeShortcut {
@@ -75,11 +138,8 @@ func (r *timeRuleStartOfThisWeek) Shortcut() TimeShortcut {
 type timeRuleEndOfThisWeek struct{}
 
 func (r *timeRuleEndOfThisWeek) Calculate(pivot time.Time) time.Time {
-	ts := thisDayEndRule.Calculate(pivot)
-	if ts.Weekday() == time.Sunday {
-		return ts
-	}
-	return ts.AddDate(0, 0, 7-int(ts.Weekday()))
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d-daysSinceMonday(pivot)+6, pivot.Location())
 }
 
 func (r *timeRuleEndOfThisWeek) Shortcut() TimeShortcut {
@@ -89,12 +149,8 @@ func (r *timeRuleEndOfThisWeek) Shortcut() TimeShortcut {
 type timeRuleStartOfPrevWeek struct{}
 
 func (r *timeRuleStartOfPrevWeek) Calculate(pivot time.Time) time.Time {
-	ts := thisDayStartRule.Calculate(pivot).AddDate(0, 0, -7)
-	if ts.Weekday() == time.Sunday {
-		return ts.AddDate(0, 0, -6)
-	}
-
-	return ts.AddDate(0, 0, -(int(ts.Weekday()) - 1))
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d-daysSinceMonday(pivot)-7, pivot.Location())
 }
 
 func (r *timeRuleStartOfPrevWeek) Shortcut() TimeShortcut { return TimeStartOfPrevWeek }
@@ -102,13 +158,8 @@ func (r *timeRuleStartOfPrevWeek) Shortcut() TimeShortcut { return TimeStartOfPr
 type timeRuleEndOfPrevWeek struct{}
 
 func (r *timeRuleEndOfPrevWeek) Calculate(pivot time.Time) time.Time {
-	ts := thisDayEndRule.Calculate(pivot)
-	if ts.Weekday() == time.Sunday {
-		ts = ts.AddDate(0, 0, -6)
-	} else {
-		ts = ts.AddDate(0, 0, -(int(ts.Weekday()) - 1))
-	}
-	return ts.AddDate(0, 0, -1)
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d-daysSinceMonday(pivot)-1, pivot.Location())
 }
 
 func (r *timeRuleEndOfPrevWeek) Shortcut() TimeShortcut { return TimeEndOfPrevWeek }
@@ -116,7 +167,7 @@ func (r *timeRuleEndOfPrevWeek) Shortcut() TimeShortcut { return TimeEndOfPrevWe
 type timeRuleStartOfThisMonth struct{}
 
 func (r *timeRuleStartOfThisMonth) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.Year(), pivot.Month(), 1, 0, 0, 0, 0, pivot.Location())
+	return startOfDay(pivot.Year(), pivot.Month(), 1, pivot.Location())
 }
 
 func (r *timeRuleStartOfThisMonth) Shortcut() TimeShortcut {
@@ -126,8 +177,7 @@ func (r *timeRuleStartOfThisMonth) Shortcut() TimeShortcut {
 type timeRuleEndOfThisMonth struct{}
 
 func (r *timeRuleEndOfThisMonth) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.Year(), pivot.Month(), 1, 23, 59, 59, 999999999, pivot.Location()).
-		AddDate(0, 1, -1)
+	return endOfDay(pivot.Year(), pivot.Month()+1, 0, pivot.Location())
 }
 
 func (r *timeRuleEndOfThisMonth) Shortcut() TimeShortcut { return TimeEndOfThisMonth }
@@ -135,8 +185,7 @@ func (r *timeRuleEndOfThisMonth) Shortcut() TimeShortcut { return TimeEndOfThisM
 type timeRuleStartOfPrevMonth struct{}
 
 func (r *timeRuleStartOfPrevMonth) Calculate(pivot time.Time) time.Time {
-	ts := pivot.AddDate(0, -1, 0)
-	return time.Date(ts.Year(), ts.Month(), 1, 0, 0, 0, 0, pivot.Location())
+	return startOfDay(pivot.Year(), pivot.Month()-1, 1, pivot.Location())
 }
 
 func (r *timeRuleStartOfPrevMonth) Shortcut() TimeShortcut {
@@ -146,8 +195,7 @@ func (r *timeRuleStartOfPrevMonth) Shortcut() TimeShortcut {
 type timeRuleEndOfPrevMonth struct{}
 
 func (r *timeRuleEndOfPrevMonth) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.Year(), pivot.Month(), 1, 23, 59, 59, 999999999, pivot.Location()).
-		AddDate(0, 0, -1)
+	return endOfDay(pivot.Year(), pivot.Month(), 0, pivot.Location())
 }
 
 func (r *timeRuleEndOfPrevMonth) Shortcut() TimeShortcut { return TimeEndOfPrevMonth }
@@ -155,9 +203,9 @@ func (r *timeRuleEndOfPrevMonth) Shortcut() TimeShortcut { return TimeEndOfPrevM
 type timeRuleStartOfThisQuart struct{}
 
 func (r *timeRuleStartOfThisQuart) Calculate(pivot time.Time) time.Time {
-	quartNum := ((pivot.Month() - 1) / 3)
+	quartNum := (pivot.Month() - 1) / 3
 	month := quartNum*3 + 1
-	return time.Date(pivot.Year(), month, 1, 0, 0, 0, 0, pivot.Location())
+	return startOfDay(pivot.Year(), month, 1, pivot.Location())
 }
 
 func (r *timeRuleStartOfThisQuart) Shortcut() TimeShortcut {
@@ -167,10 +215,9 @@ func (r *timeRuleStartOfThisQuart) Shortcut() TimeShortcut {
 type timeRuleEndOfThisQuart struct{}
 
 func (r *timeRuleEndOfThisQuart) Calculate(pivot time.Time) time.Time {
-	quartNum := int((pivot.Month() - 1) / 3)
-	offset := quartNum*3 + 3
-	return time.Date(pivot.Year(), 1, 1, 23, 59, 59, 999999999, pivot.Location()).
-		AddDate(0, offset, -1)
+	quartNum := (pivot.Month() - 1) / 3
+	month := quartNum*3 + 1
+	return endOfDay(pivot.Year(), month+3, 0, pivot.Location())
 }
 
 func (r *timeRuleEndOfThisQuart) Shortcut() TimeShortcut { return TimeEndOfThisQuart }
@@ -178,10 +225,9 @@ func (r *timeRuleEndOfThisQuart) Shortcut() TimeShortcut { return TimeEndOfThisQ
 type timeRuleStartOfPrevQuart struct{}
 
 func (r *timeRuleStartOfPrevQuart) Calculate(pivot time.Time) time.Time {
-	ts := pivot.AddDate(0, -3, 0)
-	quartNum := ((ts.Month() - 1) / 3)
+	quartNum := (pivot.Month() - 1) / 3
 	month := quartNum*3 + 1
-	return time.Date(ts.Year(), month, 1, 0, 0, 0, 0, pivot.Location())
+	return startOfDay(pivot.Year(), month-3, 1, pivot.Location())
 }
 
 func (r *timeRuleStartOfPrevQuart) Shortcut() TimeShortcut {
@@ -191,10 +237,9 @@ func (r *timeRuleStartOfPrevQuart) Shortcut() TimeShortcut {
 type timeRuleEndOfPrevQuart struct{}
 
 func (r *timeRuleEndOfPrevQuart) Calculate(pivot time.Time) time.Time {
-	quartNum := ((pivot.Month() - 1) / 3)
+	quartNum := (pivot.Month() - 1) / 3
 	month := quartNum*3 + 1
-	return time.Date(pivot.Year(), month, 1, 23, 59, 59, 999999999, pivot.Location()).
-		AddDate(0, 0, -1)
+	return endOfDay(pivot.Year(), month, 0, pivot.Location())
 }
 
 func (r *timeRuleEndOfPrevQuart) Shortcut() TimeShortcut { return TimeEndOfPrevQuart }
@@ -202,9 +247,9 @@ func (r *timeRuleEndOfPrevQuart) Shortcut() TimeShortcut { return TimeEndOfPrevQ
 type timeRuleStartOfThisHalfYear struct{}
 
 func (r *timeRuleStartOfThisHalfYear) Calculate(pivot time.Time) time.Time {
-	halfNum := ((pivot.Month() - 1) / 6)
+	halfNum := (pivot.Month() - 1) / 6
 	month := halfNum*6 + 1
-	return time.Date(pivot.Year(), month, 1, 0, 0, 0, 0, pivot.Location())
+	return startOfDay(pivot.Year(), month, 1, pivot.Location())
 }
 
 func (r *timeRuleStartOfThisHalfYear) Shortcut() TimeShortcut {
@@ -214,11 +259,9 @@ func (r *timeRuleStartOfThisHalfYear) Shortcut() TimeShortcut {
 type timeRuleEndOfThisHalfYear struct{}
 
 func (r *timeRuleEndOfThisHalfYear) Calculate(pivot time.Time) time.Time {
-	halfNum := int(((pivot.Month() - 1) / 6))
-	offset := halfNum*6 + 6
-
-	return time.Date(pivot.Year(), 1, 1, 23, 59, 59, 999999999, pivot.Location()).
-		AddDate(0, offset, -1)
+	halfNum := (pivot.Month() - 1) / 6
+	month := halfNum*6 + 1
+	return endOfDay(pivot.Year(), month+6, 0, pivot.Location())
 }
 
 func (r *timeRuleEndOfThisHalfYear) Shortcut() TimeShortcut {
@@ -228,10 +271,9 @@ func (r *timeRuleEndOfThisHalfYear) Shortcut() TimeShortcut {
 type timeRuleStartOfPrevHalfYear struct{}
 
 func (r *timeRuleStartOfPrevHalfYear) Calculate(pivot time.Time) time.Time {
-	ts := pivot.AddDate(0, -6, 0)
-	halfNum := ((ts.Month() - 1) / 6)
+	halfNum := (pivot.Month() - 1) / 6
 	month := halfNum*6 + 1
-	return time.Date(ts.Year(), month, 1, 0, 0, 0, 0, pivot.Location())
+	return startOfDay(pivot.Year(), month-6, 1, pivot.Location())
 }
 
 func (r *timeRuleStartOfPrevHalfYear) Shortcut() TimeShortcut {
@@ -241,10 +283,9 @@ func (r *timeRuleStartOfPrevHalfYear) Shortcut() TimeShortcut {
 type timeRuleEndOfPrevHalfYear struct{}
 
 func (r *timeRuleEndOfPrevHalfYear) Calculate(pivot time.Time) time.Time {
-	halfNum := ((pivot.Month() - 1) / 6)
+	halfNum := (pivot.Month() - 1) / 6
 	month := halfNum*6 + 1
-	return time.Date(pivot.Year(), month, 1, 23, 59, 59, 999999999, pivot.Location()).
-		AddDate(0, 0, -1)
+	return endOfDay(pivot.Year(), month, 0, pivot.Location())
 }
 
 func (r *timeRuleEndOfPrevHalfYear) Shortcut() TimeShortcut {
@@ -254,7 +295,7 @@ func (r *timeRuleEndOfPrevHalfYear) Shortcut() TimeShortcut {
 type timeRuleStartOfThisYear struct{}
 
 func (r *timeRuleStartOfThisYear) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.Year(), 1, 1, 0, 0, 0, 0, pivot.Location())
+	return startOfDay(pivot.Year(), 1, 1, pivot.Location())
 }
 
 func (r *timeRuleStartOfThisYear) Shortcut() TimeShortcut {
@@ -264,8 +305,7 @@ func (r *timeRuleStartOfThisYear) Shortcut() TimeShortcut {
 type timeRuleEndOfThisYear struct{}
 
 func (r *timeRuleEndOfThisYear) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.Year(), 1, 1, 23, 59, 59, 999999999, pivot.Location()).
-		AddDate(1, 0, -1)
+	return endOfDay(pivot.Year()+1, 1, 0, pivot.Location())
 }
 
 func (r *timeRuleEndOfThisYear) Shortcut() TimeShortcut { return TimeEndOfThisYear }
@@ -273,7 +313,7 @@ func (r *timeRuleEndOfThisYear) Shortcut() TimeShortcut { return TimeEndOfThisYe
 type timeRuleStartOfPrevYear struct{}
 
 func (r *timeRuleStartOfPrevYear) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.AddDate(-1, 0, 0).Year(), 1, 1, 0, 0, 0, 0, pivot.Location())
+	return startOfDay(pivot.Year()-1, 1, 1, pivot.Location())
 }
 
 func (r *timeRuleStartOfPrevYear) Shortcut() TimeShortcut { return TimeStartOfPrevYear }
@@ -281,8 +321,7 @@ func (r *timeRuleStartOfPrevYear) Shortcut() TimeShortcut { return TimeStartOfPr
 type timeRuleEndOfPrevYear struct{}
 
 func (r *timeRuleEndOfPrevYear) Calculate(pivot time.Time) time.Time {
-	return time.Date(pivot.Year(), 1, 1, 23, 59, 59, 999999999, pivot.Location()).
-		AddDate(0, 0, -1)
+	return endOfDay(pivot.Year(), 1, 0, pivot.Location())
 }
 
 func (r *timeRuleEndOfPrevYear) Shortcut() TimeShortcut { return TimeEndOfPrevYear }
@@ -290,8 +329,8 @@ func (r *timeRuleEndOfPrevYear) Shortcut() TimeShortcut { return TimeEndOfPrevYe
 type timeRuleStartOfThisWeekS struct{}
 
 func (r *timeRuleStartOfThisWeekS) Calculate(pivot time.Time) time.Time {
-	ts := thisDayStartRule.Calculate(pivot)
-	return ts.AddDate(0, 0, -(int(ts.Weekday())))
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d-int(pivot.Weekday()), pivot.Location())
 }
 
 func (r *timeRuleStartOfThisWeekS) Shortcut() TimeShortcut {
@@ -301,8 +340,8 @@ func (r *timeRuleStartOfThisWeekS) Shortcut() TimeShortcut {
 type timeRuleEndOfThisWeekS struct{}
 
 func (r *timeRuleEndOfThisWeekS) Calculate(pivot time.Time) time.Time {
-	ts := thisDayEndRule.Calculate(pivot)
-	return ts.AddDate(0, 0, 6-int(ts.Weekday()))
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d-int(pivot.Weekday())+6, pivot.Location())
 }
 
 func (r *timeRuleEndOfThisWeekS) Shortcut() TimeShortcut {
@@ -312,8 +351,8 @@ func (r *timeRuleEndOfThisWeekS) Shortcut() TimeShortcut {
 type timeRuleStartOfPrevWeekS struct{}
 
 func (r *timeRuleStartOfPrevWeekS) Calculate(pivot time.Time) time.Time {
-	ts := thisDayStartRule.Calculate(pivot).AddDate(0, 0, -7)
-	return ts.AddDate(0, 0, -(int(ts.Weekday())))
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d-int(pivot.Weekday())-7, pivot.Location())
 }
 
 func (r *timeRuleStartOfPrevWeekS) Shortcut() TimeShortcut { return TimeStartOfPrevWeek }
@@ -321,8 +360,142 @@ func (r *timeRuleStartOfPrevWeekS) Shortcut() TimeShortcut { return TimeStartOfP
 type timeRuleEndOfPrevWeekS struct{}
 
 func (r *timeRuleEndOfPrevWeekS) Calculate(pivot time.Time) time.Time {
-	ts := thisDayEndRule.Calculate(pivot)
-	return ts.AddDate(0, 0, -(int(ts.Weekday()))-1)
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d-int(pivot.Weekday())-1, pivot.Location())
 }
 
 func (r *timeRuleEndOfPrevWeekS) Shortcut() TimeShortcut { return TimeEndOfPrevWeek }
+
+type timeRuleStartOfNextDay struct{}
+
+func (r *timeRuleStartOfNextDay) Calculate(pivot time.Time) time.Time {
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d+1, pivot.Location())
+}
+
+func (r *timeRuleStartOfNextDay) Shortcut() TimeShortcut { return TimeStartOfNextDay }
+
+type timeRuleEndOfNextDay struct{}
+
+func (r *timeRuleEndOfNextDay) Calculate(pivot time.Time) time.Time {
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d+1, pivot.Location())
+}
+
+func (r *timeRuleEndOfNextDay) Shortcut() TimeShortcut { return TimeEndOfNextDay }
+
+type timeRuleStartOfNextWeek struct{}
+
+func (r *timeRuleStartOfNextWeek) Calculate(pivot time.Time) time.Time {
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d-daysSinceMonday(pivot)+7, pivot.Location())
+}
+
+func (r *timeRuleStartOfNextWeek) Shortcut() TimeShortcut { return TimeStartOfNextWeek }
+
+type timeRuleEndOfNextWeek struct{}
+
+func (r *timeRuleEndOfNextWeek) Calculate(pivot time.Time) time.Time {
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d-daysSinceMonday(pivot)+13, pivot.Location())
+}
+
+func (r *timeRuleEndOfNextWeek) Shortcut() TimeShortcut { return TimeEndOfNextWeek }
+
+type timeRuleStartOfNextMonth struct{}
+
+func (r *timeRuleStartOfNextMonth) Calculate(pivot time.Time) time.Time {
+	return startOfDay(pivot.Year(), pivot.Month()+1, 1, pivot.Location())
+}
+
+func (r *timeRuleStartOfNextMonth) Shortcut() TimeShortcut {
+	return TimeStartOfNextMonth
+}
+
+type timeRuleEndOfNextMonth struct{}
+
+func (r *timeRuleEndOfNextMonth) Calculate(pivot time.Time) time.Time {
+	return endOfDay(pivot.Year(), pivot.Month()+2, 0, pivot.Location())
+}
+
+func (r *timeRuleEndOfNextMonth) Shortcut() TimeShortcut { return TimeEndOfNextMonth }
+
+type timeRuleStartOfNextQuart struct{}
+
+func (r *timeRuleStartOfNextQuart) Calculate(pivot time.Time) time.Time {
+	quartNum := (pivot.Month() - 1) / 3
+	month := quartNum*3 + 1
+	return startOfDay(pivot.Year(), month+3, 1, pivot.Location())
+}
+
+func (r *timeRuleStartOfNextQuart) Shortcut() TimeShortcut {
+	return TimeStartOfNextQuart
+}
+
+type timeRuleEndOfNextQuart struct{}
+
+func (r *timeRuleEndOfNextQuart) Calculate(pivot time.Time) time.Time {
+	quartNum := (pivot.Month() - 1) / 3
+	month := quartNum*3 + 1
+	return endOfDay(pivot.Year(), month+6, 0, pivot.Location())
+}
+
+func (r *timeRuleEndOfNextQuart) Shortcut() TimeShortcut { return TimeEndOfNextQuart }
+
+type timeRuleStartOfNextHalfYear struct{}
+
+func (r *timeRuleStartOfNextHalfYear) Calculate(pivot time.Time) time.Time {
+	halfNum := (pivot.Month() - 1) / 6
+	month := halfNum*6 + 1
+	return startOfDay(pivot.Year(), month+6, 1, pivot.Location())
+}
+
+func (r *timeRuleStartOfNextHalfYear) Shortcut() TimeShortcut {
+	return TimeStartOfNextHalfYear
+}
+
+type timeRuleEndOfNextHalfYear struct{}
+
+func (r *timeRuleEndOfNextHalfYear) Calculate(pivot time.Time) time.Time {
+	halfNum := (pivot.Month() - 1) / 6
+	month := halfNum*6 + 1
+	return endOfDay(pivot.Year(), month+12, 0, pivot.Location())
+}
+
+func (r *timeRuleEndOfNextHalfYear) Shortcut() TimeShortcut {
+	return TimeEndOfNextHalfYear
+}
+
+type timeRuleStartOfNextYear struct{}
+
+func (r *timeRuleStartOfNextYear) Calculate(pivot time.Time) time.Time {
+	return startOfDay(pivot.Year()+1, 1, 1, pivot.Location())
+}
+
+func (r *timeRuleStartOfNextYear) Shortcut() TimeShortcut { return TimeStartOfNextYear }
+
+type timeRuleEndOfNextYear struct{}
+
+func (r *timeRuleEndOfNextYear) Calculate(pivot time.Time) time.Time {
+	return endOfDay(pivot.Year()+2, 1, 0, pivot.Location())
+}
+
+func (r *timeRuleEndOfNextYear) Shortcut() TimeShortcut { return TimeEndOfNextYear }
+
+type timeRuleStartOfNextWeekS struct{}
+
+func (r *timeRuleStartOfNextWeekS) Calculate(pivot time.Time) time.Time {
+	y, m, d := pivot.Date()
+	return startOfDay(y, m, d-int(pivot.Weekday())+7, pivot.Location())
+}
+
+func (r *timeRuleStartOfNextWeekS) Shortcut() TimeShortcut { return TimeStartOfNextWeek }
+
+type timeRuleEndOfNextWeekS struct{}
+
+func (r *timeRuleEndOfNextWeekS) Calculate(pivot time.Time) time.Time {
+	y, m, d := pivot.Date()
+	return endOfDay(y, m, d-int(pivot.Weekday())+13, pivot.Location())
+}
+
+func (r *timeRuleEndOfNextWeekS) Shortcut() TimeShortcut { return TimeEndOfNextWeek }
@@ -172,6 +172,12 @@ func TestTimeRules_Perform(t *testing.T) {
 			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
 			expected: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:     "StartOfPrevMonth(the last day of a month)",
+			rule:     &timeRuleStartOfPrevMonth{},
+			date:     time.Date(2019, 3, 31, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
 		{
 			name:     "EndOfPrevMonth",
 			rule:     &timeRuleEndOfPrevMonth{},
@@ -184,6 +190,12 @@ func TestTimeRules_Perform(t *testing.T) {
 			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
 			expected: time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:     "StartOfPrevQuart(the last day of a quart)",
+			rule:     &timeRuleStartOfPrevQuart{},
+			date:     time.Date(2019, 12, 31, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
 		{
 			name:     "EndOfPrevQuart",
 			rule:     &timeRuleEndOfPrevQuart{},
@@ -196,6 +208,12 @@ func TestTimeRules_Perform(t *testing.T) {
 			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
 			expected: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name:     "StartOfPrevHalfYear(the last day of a half year)",
+			rule:     &timeRuleStartOfPrevHalfYear{},
+			date:     time.Date(2019, 12, 31, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
 		{
 			name:     "EndOfPrevHalfYear",
 			rule:     &timeRuleEndOfPrevHalfYear{},
@@ -214,6 +232,108 @@ func TestTimeRules_Perform(t *testing.T) {
 			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
 			expected: time.Date(2018, 12, 31, 23, 59, 59, 999999999, time.UTC),
 		},
+		{
+			name:     "StartOfNextDay",
+			rule:     &timeRuleStartOfNextDay{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 12, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfNextDay",
+			rule:     &timeRuleEndOfNextDay{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 12, 12, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextWeek",
+			rule:     &timeRuleStartOfNextWeek{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 12, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfNextWeek",
+			rule:     &timeRuleEndOfNextWeek{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 12, 22, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextWeek(Sunday)",
+			rule:     &timeRuleStartOfNextWeek{},
+			date:     time.Date(2020, 8, 9, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2020, 8, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfNextWeek(Sunday)",
+			rule:     &timeRuleEndOfNextWeek{},
+			date:     time.Date(2020, 8, 9, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2020, 8, 16, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextWeekS(the first day of the week is Sunday)",
+			rule:     &timeRuleStartOfNextWeekS{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 12, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfNextWeekS(the first day of the week is Sunday)",
+			rule:     &timeRuleEndOfNextWeekS{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2019, 12, 21, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextMonth",
+			rule:     &timeRuleStartOfNextMonth{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfNextMonth",
+			rule:     &timeRuleEndOfNextMonth{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 1, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "EndOfNextMonth(the last day of a month)",
+			rule:     &timeRuleEndOfNextMonth{},
+			date:     time.Date(2020, 1, 31, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 2, 29, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextQuart",
+			rule:     &timeRuleStartOfNextQuart{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfNextQuart",
+			rule:     &timeRuleEndOfNextQuart{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextHalfYear",
+			rule:     &timeRuleStartOfNextHalfYear{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfNextHalfYear",
+			rule:     &timeRuleEndOfNextHalfYear{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 6, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "StartOfNextYear",
+			rule:     &timeRuleStartOfNextYear{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "EndOfNextYear",
+			rule:     &timeRuleEndOfNextYear{},
+			date:     time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			expected: time.Date(2020, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -21,3 +21,21 @@ func (s *defaultTimeStringer) String(t time.Time) string {
 
 	return t.Format(format)
 }
+
+// ZoneTimeStringer formats times with the abbreviated name of the zone,
+// e.g. "2020-08-01 00:00:00 MSK", so the location of a factory is visible.
+var ZoneTimeStringer = NewLayoutTimeStringer("2006-01-02 15:04:05 MST")
+
+type layoutTimeStringer struct {
+	layout string
+}
+
+// NewLayoutTimeStringer creates a time stringer which formats times
+// by the layout (see time.Time Format method).
+func NewLayoutTimeStringer(layout string) TimeStringer {
+	return &layoutTimeStringer{layout: layout}
+}
+
+func (s *layoutTimeStringer) String(t time.Time) string {
+	return t.Format(s.layout)
+}
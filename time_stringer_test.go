@@ -21,3 +21,21 @@ func TestDefaultTimeStringer(t *testing.T) {
 		t.Errorf("expected: '%s', but actual: '%s'", expected, actual)
 	}
 }
+
+func TestLayoutTimeStringer(t *testing.T) {
+	ts := time.Date(2019, 12, 11, 0, 2, 1, 6, time.FixedZone("MSK", 3*60*60))
+
+	expected := "2019-12-11 00:02:01 MSK"
+
+	actual := rdate.ZoneTimeStringer.String(ts)
+	if actual != expected {
+		t.Errorf("expected: '%s', but actual: '%s'", expected, actual)
+	}
+
+	expected = "11.12.2019"
+
+	actual = rdate.NewLayoutTimeStringer("02.01.2006").String(ts)
+	if actual != expected {
+		t.Errorf("expected: '%s', but actual: '%s'", expected, actual)
+	}
+}
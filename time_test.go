@@ -242,6 +242,78 @@ func TestDefaultTimeFactory_existenceOfRules(t *testing.T) {
 			sc:       rdate.TimeEndOfPrevYear,
 			expected: time.Date(2018, 12, 31, 23, 59, 59, 999999999, time.UTC),
 		},
+		{
+			name:     "TimeStartOfNextDay",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextDay,
+			expected: time.Date(2019, 12, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextDay",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextDay,
+			expected: time.Date(2019, 12, 12, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextWeek",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextWeek,
+			expected: time.Date(2019, 12, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextWeek",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextWeek,
+			expected: time.Date(2019, 12, 22, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextMonth",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextMonth,
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextMonth",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextMonth,
+			expected: time.Date(2020, 1, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextQuart",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextQuart,
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextQuart",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextQuart,
+			expected: time.Date(2020, 3, 31, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextHalfYear",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextHalfYear,
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextHalfYear",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextHalfYear,
+			expected: time.Date(2020, 6, 30, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfNextYear",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfNextYear,
+			expected: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfNextYear",
+			pivot:    time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfNextYear,
+			expected: time.Date(2020, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -291,6 +363,87 @@ func TestTimeFactory_Extend(t *testing.T) {
 	timeEqual(t, tm, time.Date(2019, 12, 11, 0, 2, 1, 6, time.UTC))
 }
 
+func TestTimeFactory_Remove(t *testing.T) {
+	pivot := time.Date(2010, 3, 1, 0, 2, 1, 6, time.UTC)
+
+	f := rdate.NewTimeFactory()
+	f.Remove(rdate.TimeStartOfThisMonth, "unknown shortcut")
+
+	if _, ok := f.Make(pivot, rdate.TimeStartOfThisMonth); ok {
+		t.Errorf("expected ok = false but it's true")
+	}
+	if _, ok := f.Make(pivot, "start 3 months ago"); ok {
+		t.Errorf("expected ok = false but it's true")
+	}
+
+	tm, ok := f.Make(pivot, rdate.TimeEndOfThisMonth)
+	if !ok {
+		t.Errorf("expected ok but it isn't")
+	}
+	timeEqual(t, tm, time.Date(2010, 3, 31, 23, 59, 59, 999999999, time.UTC))
+
+	// the presets which the grammar can rebuild stay removed
+	f = rdate.NewTimeFactory()
+	f.Remove(rdate.TimeStartOfPrevMonth, rdate.TimeEndOfNextYear)
+
+	for _, sc := range []rdate.TimeShortcut{rdate.TimeStartOfPrevMonth, rdate.TimeEndOfNextYear} {
+		if _, ok := f.Make(pivot, sc); ok {
+			t.Errorf("%s: expected ok = false but it's true", sc)
+		}
+		if _, ok := f.Clone().Make(pivot, sc); ok {
+			t.Errorf("%s: expected ok = false in the copy but it's true", sc)
+		}
+	}
+	timeEqual(t, f.Require(pivot, "start 1 month ago"), time.Date(2010, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	// a removed shortcut is known again after it's registered
+	f.Extend([]rdate.TimeRule{&steppedRule{sc: rdate.TimeStartOfPrevMonth}})
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfPrevMonth), time.Date(2010, 2, 1, 0, 0, 0, 0, time.UTC))
+}
+
+func TestTimeFactory_Clone(t *testing.T) {
+	pivot := time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC)
+
+	testCases := []struct {
+		name string
+		f    rdate.ConfigurableTimeFactory
+	}{
+		{name: "safe", f: rdate.NewTimeFactory()},
+		{name: "nonblocking", f: rdate.NewNonblockingTimeFactory()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.f.SetDayStart(time.Hour)
+			tc.f.Extend([]rdate.TimeRule{&testTimeRule{}})
+
+			c := tc.f.Clone()
+			c.Extend([]rdate.TimeRule{&describedTimeRule{}})
+			c.Remove("my test time")
+			c.SetStartOfWeek(rdate.StartOfWeekSunday)
+
+			if _, ok := c.Make(pivot, "lunch"); !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+			if _, ok := tc.f.Make(pivot, "lunch"); ok {
+				t.Errorf("expected ok = false but it's true")
+			}
+
+			if _, ok := c.Make(pivot, "my test time"); ok {
+				t.Errorf("expected ok = false but it's true")
+			}
+			if _, ok := tc.f.Make(pivot, "my test time"); !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			timeEqual(t, c.Require(pivot, rdate.TimeStartOfThisWeek),
+				time.Date(2020, 7, 5, 1, 0, 0, 0, time.UTC))
+			timeEqual(t, tc.f.Require(pivot, rdate.TimeStartOfThisWeek),
+				time.Date(2020, 7, 6, 1, 0, 0, 0, time.UTC))
+		})
+	}
+}
+
 type testTimeStringer struct{}
 
 func (s *testTimeStringer) String(t time.Time) string { return "test stringer" }
@@ -365,6 +518,20 @@ func TestTimeFactory_SetStartOfWeek(t *testing.T) {
 			expectedMonday: time.Date(2020, 7, 5, 23, 59, 59, 999999999, time.UTC),
 			expectedSunday: time.Date(2020, 7, 4, 23, 59, 59, 999999999, time.UTC),
 		},
+		{
+			name:           "StartOfNextWeek",
+			ts:             time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC),
+			sc:             rdate.TimeStartOfNextWeek,
+			expectedMonday: time.Date(2020, 7, 13, 0, 0, 0, 0, time.UTC),
+			expectedSunday: time.Date(2020, 7, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "TimeEndOfNextWeek",
+			ts:             time.Date(2020, 7, 8, 0, 2, 1, 6, time.UTC),
+			sc:             rdate.TimeEndOfNextWeek,
+			expectedMonday: time.Date(2020, 7, 19, 23, 59, 59, 999999999, time.UTC),
+			expectedSunday: time.Date(2020, 7, 18, 23, 59, 59, 999999999, time.UTC),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -389,6 +556,239 @@ func TestTimeFactory_SetStartOfWeek(t *testing.T) {
 	}
 }
 
+func TestTimeFactory_SetDayStart(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("tzdata is unavailable: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		pivot    time.Time
+		sc       rdate.TimeShortcut
+		expected time.Time
+	}{
+		{
+			name:     "TimeAsIs",
+			pivot:    time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeAsIs,
+			expected: time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisDay",
+			pivot:    time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisDay,
+			expected: time.Date(2020, 8, 10, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisDay",
+			pivot:    time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisDay,
+			expected: time.Date(2020, 8, 11, 5, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisDay(after the boundary)",
+			pivot:    time.Date(2020, 8, 11, 6, 0, 0, 0, time.UTC),
+			sc:       rdate.TimeStartOfThisDay,
+			expected: time.Date(2020, 8, 11, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfPrevDay",
+			pivot:    time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevDay,
+			expected: time.Date(2020, 8, 9, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisWeek",
+			pivot:    time.Date(2020, 8, 10, 3, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfThisWeek,
+			expected: time.Date(2020, 8, 3, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisWeek",
+			pivot:    time.Date(2020, 8, 10, 3, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisWeek,
+			expected: time.Date(2020, 8, 10, 5, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "TimeStartOfPrevMonth",
+			pivot:    time.Date(2020, 9, 1, 5, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeStartOfPrevMonth,
+			expected: time.Date(2020, 7, 1, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeEndOfThisYear",
+			pivot:    time.Date(2021, 1, 1, 5, 2, 1, 6, time.UTC),
+			sc:       rdate.TimeEndOfThisYear,
+			expected: time.Date(2021, 1, 1, 5, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:     "start 2 days ago",
+			pivot:    time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC),
+			sc:       "start 2 days ago",
+			expected: time.Date(2020, 8, 8, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "TimeStartOfThisDay(DST)",
+			pivot:    time.Date(2021, 3, 14, 10, 0, 0, 0, ny),
+			sc:       rdate.TimeStartOfThisDay,
+			expected: time.Date(2021, 3, 14, 6, 0, 0, 0, ny),
+		},
+		{
+			name:     "TimeAsIs(DST)",
+			pivot:    time.Date(2021, 3, 14, 8, 30, 0, 0, ny),
+			sc:       rdate.TimeAsIs,
+			expected: time.Date(2021, 3, 14, 8, 30, 0, 0, ny),
+		},
+		{
+			name:     "TimeAsIs(the second 01:30)",
+			pivot:    time.Date(2021, 11, 7, 1, 30, 0, 0, ny).Add(time.Hour),
+			sc:       rdate.TimeAsIs,
+			expected: time.Date(2021, 11, 7, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "as is this day(the second 01:30)",
+			pivot:    time.Date(2021, 11, 7, 1, 30, 0, 0, ny).Add(time.Hour),
+			sc:       "as is this day",
+			expected: time.Date(2021, 11, 7, 6, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "as is prev day(DST)",
+			pivot:    time.Date(2021, 3, 15, 8, 30, 0, 0, ny),
+			sc:       "as is prev day",
+			expected: time.Date(2021, 3, 14, 8, 30, 0, 0, ny),
+		},
+		{
+			name:     "as is prev day(before the boundary)",
+			pivot:    time.Date(2021, 3, 15, 3, 30, 0, 0, ny),
+			sc:       "as is prev day",
+			expected: time.Date(2021, 3, 14, 3, 30, 0, 0, ny),
+		},
+		{
+			name:     "TimeEndOfThisDay(DST)",
+			pivot:    time.Date(2021, 11, 7, 1, 30, 0, 0, ny).Add(time.Hour),
+			sc:       rdate.TimeEndOfThisDay,
+			expected: time.Date(2021, 11, 7, 5, 59, 59, 999999999, ny),
+		},
+	}
+
+	f := rdate.NewTimeFactory()
+	f.SetDayStart(6 * time.Hour)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok := f.Make(tc.pivot, tc.sc)
+			if !ok {
+				t.Errorf("expected ok but it isn't")
+			}
+
+			timeEqual(t, actual, tc.expected)
+		})
+	}
+}
+
+func TestTimeFactory_SetDayStartInvalid(t *testing.T) {
+	f := rdate.NewTimeFactory()
+	f.SetDayStart(-time.Hour)
+	f.SetDayStart(24 * time.Hour)
+
+	tm := f.Require(time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC), rdate.TimeStartOfThisDay)
+	timeEqual(t, tm, time.Date(2020, 8, 11, 0, 0, 0, 0, time.UTC))
+}
+
+func TestTimeFactory_SetPrecision(t *testing.T) {
+	pivot := time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC)
+
+	testCases := []struct {
+		precision time.Duration
+		sc        rdate.TimeShortcut
+		expected  time.Time
+	}{
+		{
+			precision: time.Second,
+			sc:        rdate.TimeEndOfPrevDay,
+			expected:  time.Date(2020, 8, 10, 23, 59, 59, 0, time.UTC),
+		},
+		{
+			precision: time.Millisecond,
+			sc:        rdate.TimeEndOfThisMonth,
+			expected:  time.Date(2020, 8, 31, 23, 59, 59, 999000000, time.UTC),
+		},
+		{
+			precision: time.Microsecond,
+			sc:        "end 2 weeks ago",
+			expected:  time.Date(2020, 8, 2, 23, 59, 59, 999999000, time.UTC),
+		},
+		{
+			precision: time.Nanosecond,
+			sc:        rdate.TimeEndOfPrevBusinessDay,
+			expected:  time.Date(2020, 8, 10, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			precision: time.Second,
+			sc:        rdate.TimeStartOfThisDay,
+			expected:  time.Date(2020, 8, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			precision: time.Second,
+			sc:        rdate.TimeAsIs,
+			expected:  pivot,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.precision.String()+" "+string(tc.sc), func(t *testing.T) {
+			f := rdate.NewTimeFactory(rdate.WithPrecision(tc.precision))
+			timeEqual(t, f.MustResolve(pivot, tc.sc), tc.expected)
+		})
+	}
+}
+
+func TestTimeFactory_SetPrecisionInvalid(t *testing.T) {
+	f := rdate.NewTimeFactory()
+	f.SetPrecision(time.Second)
+	f.SetPrecision(time.Minute)
+	f.SetPrecision(0)
+
+	tm := f.Require(time.Date(2020, 8, 11, 3, 2, 1, 6, time.UTC), rdate.TimeEndOfThisDay)
+	timeEqual(t, tm, time.Date(2020, 8, 11, 23, 59, 59, 0, time.UTC))
+}
+
+func TestTimeFactory_SetLocation(t *testing.T) {
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Skipf("tzdata is unavailable: %v", err)
+	}
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("tzdata is unavailable: %v", err)
+	}
+
+	pivot := time.Date(2020, 8, 1, 3, 0, 0, 0, time.UTC)
+
+	f := rdate.NewTimeFactory()
+	f.SetStringer(rdate.ZoneTimeStringer)
+
+	f.SetLocation(la)
+	tm := f.Require(pivot, rdate.TimeStartOfThisMonth)
+	timeEqual(t, tm, time.Date(2020, 7, 1, 0, 0, 0, 0, la))
+	if tm.Time().Location() != la {
+		t.Errorf("expected: %s, but actual: %s", la, tm.Time().Location())
+	}
+	if tm.String() != "2020-07-01 00:00:00 PDT" {
+		t.Errorf("expected: '2020-07-01 00:00:00 PDT', but actual: '%s'", tm.String())
+	}
+
+	f.SetLocation(moscow)
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisMonth),
+		time.Date(2020, 8, 1, 0, 0, 0, 0, moscow))
+	timeEqual(t, f.Require(pivot, rdate.TimeAsIs), pivot)
+
+	f.SetLocation(nil)
+	timeEqual(t, f.Require(pivot, rdate.TimeStartOfThisMonth),
+		time.Date(2020, 8, 1, 0, 0, 0, 0, time.UTC))
+}
+
 func TestSetDefaultTimeFactory(t *testing.T) {
 	pivot := time.Date(2010, 3, 1, 0, 2, 1, 6, time.UTC)
 
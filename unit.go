@@ -0,0 +1,30 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate
+
+// Unit is a calendar unit, its bounds are calculated by the rules
+// of a time factory, e.g. UnitWeek follows StartOfWeek
+// and UnitQuart follows the fiscal year.
+type Unit int8
+
+const (
+	UnitDay      = Unit(unitDay)
+	UnitWeek     = Unit(unitWeek)
+	UnitMonth    = Unit(unitMonth)
+	UnitQuart    = Unit(unitQuart)
+	UnitHalfYear = Unit(unitHalfYear)
+	UnitYear     = Unit(unitYear)
+	UnitISOYear  = Unit(unitISOYear)
+)
+
+func (u Unit) valid() bool {
+	_, ok := unitNames[unit(u)]
+	return ok
+}
+
+// String returns the name of the unit as it's used in shortcuts, e.g. "half year".
+func (u Unit) String() string {
+	return unitNames[unit(u)]
+}
@@ -0,0 +1,29 @@
+// Copyright © 2020 Danila Petrunko. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package rdate_test
+
+import (
+	"testing"
+
+	"github.com/petrunkodg/rdate"
+)
+
+func TestUnit_String(t *testing.T) {
+	testCases := []struct {
+		u        rdate.Unit
+		expected string
+	}{
+		{u: rdate.UnitDay, expected: "day"},
+		{u: rdate.UnitHalfYear, expected: "half year"},
+		{u: rdate.UnitISOYear, expected: "iso year"},
+		{u: rdate.Unit(0), expected: ""},
+	}
+
+	for _, tc := range testCases {
+		if actual := tc.u.String(); actual != tc.expected {
+			t.Errorf("expected %q, got %q", tc.expected, actual)
+		}
+	}
+}